@@ -0,0 +1,53 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// IsPermissionDenied reports whether err, or any error it wraps, is a NOPERM
+// reply from an ACL user lacking permission for a command or key
+func IsPermissionDenied(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if strings.HasPrefix(strings.TrimSpace(err.Error()), "NOPERM") {
+			return true
+		}
+	}
+	return false
+}
+
+// permissionHook reports NOPERM failures to a callback
+type permissionHook struct {
+	onDenied func(cmd string, err error)
+}
+
+func (h permissionHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h permissionHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		if IsPermissionDenied(err) {
+			h.onDenied(cmd.Name(), err)
+		}
+		return err
+	}
+}
+
+func (h permissionHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			if IsPermissionDenied(cmd.Err()) {
+				h.onDenied(cmd.Name(), cmd.Err())
+			}
+		}
+		return err
+	}
+}
+
+var _ redis.Hook = permissionHook{}
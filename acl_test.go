@@ -0,0 +1,71 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestIsPermissionDenied tests NOPERM classification
+func TestIsPermissionDenied(t *testing.T) {
+	noperm := errors.New("NOPERM User app has no permissions to run the 'flushall' command")
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"noperm", noperm, true},
+		{"wrapped noperm", fmt.Errorf("flush cache: %w", noperm), true},
+		{"leading whitespace", errors.New(" NOPERM this user has no permissions to access one of the keys"), true},
+		{"other error", errors.New("ERR unknown command"), false},
+		{"mentions noperm later", errors.New("ERR something NOPERM"), false},
+		{"redis nil", redis.Nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPermissionDenied(tt.err); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestPermissionHook tests that NOPERM failures reach the callback
+func TestPermissionHook(t *testing.T) {
+	noperm := errors.New("NOPERM User app has no permissions to run the 'get' command")
+
+	var denied []string
+	hook := permissionHook{onDenied: func(cmd string, err error) {
+		denied = append(denied, cmd)
+	}}
+
+	process := hook.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error {
+		if cmd.Name() == "get" {
+			cmd.SetErr(noperm)
+			return noperm
+		}
+		return nil
+	})
+
+	ctx := context.Background()
+	if err := process(ctx, redis.NewStringCmd(ctx, "get", "k")); err != noperm {
+		t.Errorf("expected the original error, got %v", err)
+	}
+	process(ctx, redis.NewStatusCmd(ctx, "ping"))
+
+	pipeline := hook.ProcessPipelineHook(func(ctx context.Context, cmds []redis.Cmder) error {
+		cmds[1].SetErr(noperm)
+		return noperm
+	})
+	pipeline(ctx, []redis.Cmder{redis.NewStatusCmd(ctx, "ping"), redis.NewStringCmd(ctx, "set", "k", "v")})
+
+	if fmt.Sprint(denied) != "[get set]" {
+		t.Errorf("expected callbacks for [get set], got %v", denied)
+	}
+}
@@ -0,0 +1,48 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Dump returns the serialized form of the value at key, suitable for
+// Restore. It returns ErrCacheMiss when the key does not exist.
+func (c *Client) Dump(ctx context.Context, key string) ([]byte, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	data, err := st.rdb.Dump(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Restore recreates key from data produced by Dump, expiring after ttl (0
+// for no expiry). Unless replace is set, it returns ErrKeyExists when the
+// key already exists.
+func (c *Client) Restore(ctx context.Context, key string, data []byte, ttl time.Duration, replace bool) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	var err error
+	if replace {
+		err = st.rdb.RestoreReplace(ctx, key, ttl, string(data)).Err()
+	} else {
+		err = st.rdb.Restore(ctx, key, ttl, string(data)).Err()
+	}
+	if err != nil && strings.HasPrefix(err.Error(), "BUSYKEY") {
+		return fmt.Errorf("%w: %s", ErrKeyExists, key)
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestDumpRestore tests single-key backup and restore
+func TestDumpRestore(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.Dump(context.Background(), "k"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+		if err := client.Restore(context.Background(), "k", nil, 0, false); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:dump"
+	copyKey := "rediskit:test:dump:copy"
+	defer client.Del(ctx, key, copyKey)
+
+	t.Run("missing key", func(t *testing.T) {
+		if _, err := client.Dump(ctx, key+":missing"); !errors.Is(err, ErrCacheMiss) {
+			t.Errorf("expected ErrCacheMiss, got %v", err)
+		}
+	})
+
+	client.Set(ctx, key, "snapshot", 0)
+	data, err := client.Dump(ctx, key)
+	if isUnknownCommand(err) {
+		t.Skip("DUMP not supported by this server")
+	}
+	if err != nil {
+		t.Fatalf("Dump: %v", err)
+	}
+
+	t.Run("restore to a new key", func(t *testing.T) {
+		if err := client.Restore(ctx, copyKey, data, time.Hour, false); err != nil {
+			t.Fatalf("Restore: %v", err)
+		}
+		if got := client.Get(ctx, copyKey).Val(); got != "snapshot" {
+			t.Errorf("unexpected restored value %q", got)
+		}
+		if ttl := client.TTL(ctx, copyKey).Val(); ttl <= 0 {
+			t.Errorf("expected a ttl, got %v", ttl)
+		}
+	})
+
+	t.Run("existing key without replace", func(t *testing.T) {
+		if err := client.Restore(ctx, copyKey, data, 0, false); !errors.Is(err, ErrKeyExists) {
+			t.Errorf("expected ErrKeyExists, got %v", err)
+		}
+	})
+
+	t.Run("existing key with replace", func(t *testing.T) {
+		if err := client.Restore(ctx, copyKey, data, 0, true); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
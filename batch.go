@@ -0,0 +1,39 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// pipelineChunkSize bounds the number of commands sent in one pipeline
+const pipelineChunkSize = 1000
+
+// MSetEX sets every key in pairs with the same TTL. Plain MSET cannot set
+// expiries, so the writes are pipelined as SET ... EX, in chunks for large
+// maps. Values are written as go-redis encodes command arguments.
+func (c *Client) MSetEX(ctx context.Context, pairs map[string]any, ttl time.Duration) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("%w: ttl must be greater than 0", ErrInvalidArgument)
+	}
+
+	pipe := st.rdb.Pipeline()
+	for key, value := range pairs {
+		pipe.Set(ctx, key, value, ttl)
+		if pipe.Len() == pipelineChunkSize {
+			if _, err := pipe.Exec(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	if pipe.Len() > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
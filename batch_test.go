@@ -0,0 +1,103 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestMSetEX tests pipelined sets with a uniform TTL
+func TestMSetEX(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.MSetEX(context.Background(), nil, time.Minute); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if err := client.MSetEX(context.Background(), map[string]any{"k": "v"}, 0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("with valid client", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+
+		pairs := make(map[string]any, pipelineChunkSize+10)
+		keys := make([]string, 0, len(pairs))
+		for i := 0; i < pipelineChunkSize+10; i++ {
+			key := fmt.Sprintf("rediskit:test:msetex:%d", i)
+			pairs[key] = i
+			keys = append(keys, key)
+		}
+		defer client.Del(ctx, keys...)
+
+		if err := client.MSetEX(ctx, pairs, time.Hour); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, key := range []string{keys[0], keys[len(keys)-1]} {
+			if v := client.Get(ctx, key).Val(); v != fmt.Sprint(pairs[key]) {
+				t.Errorf("%s: got %q, want %v", key, v, pairs[key])
+			}
+			if ttl := client.TTL(ctx, key).Val(); ttl <= 0 || ttl > time.Hour {
+				t.Errorf("%s: unexpected ttl %v", key, ttl)
+			}
+		}
+	})
+}
+
+func benchmarkPairs(n int) map[string]any {
+	pairs := make(map[string]any, n)
+	for i := 0; i < n; i++ {
+		pairs[fmt.Sprintf("rediskit:bench:msetex:%d", i)] = "value"
+	}
+	return pairs
+}
+
+// BenchmarkMSetEX measures pipelined sets of 100 keys
+func BenchmarkMSetEX(b *testing.B) {
+	client, err := NewClient(nil)
+	if err != nil || client.HealthCheck() != nil {
+		b.Skip("Redis not available for benchmarking")
+	}
+	defer client.Close()
+	ctx := context.Background()
+	pairs := benchmarkPairs(100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := client.MSetEX(ctx, pairs, time.Minute); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkIndividualSets measures 100 sequential SET ... EX calls
+func BenchmarkIndividualSets(b *testing.B) {
+	client, err := NewClient(nil)
+	if err != nil || client.HealthCheck() != nil {
+		b.Skip("Redis not available for benchmarking")
+	}
+	defer client.Close()
+	ctx := context.Background()
+	pairs := benchmarkPairs(100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for key, value := range pairs {
+			if err := client.Set(ctx, key, value, time.Minute).Err(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// BitCountUnit selects whether a BITCOUNT range is measured in bytes or bits
+type BitCountUnit string
+
+const (
+	BitCountByte BitCountUnit = "byte"
+	BitCountBit  BitCountUnit = "bit" // requires Redis 7.0+
+)
+
+// BitCountOptions restricts BitCount to a range of the string. Start and End
+// must be set together; Unit defaults to bytes.
+type BitCountOptions struct {
+	Start *int64
+	End   *int64
+	Unit  BitCountUnit
+}
+
+// BitCount counts the set bits stored at key, optionally within a byte or
+// bit range. A missing key counts as zero.
+func (c *Client) BitCount(ctx context.Context, key string, opts BitCountOptions) (int64, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return 0, ErrNilClient
+	}
+	if (opts.Start == nil) != (opts.End == nil) {
+		return 0, fmt.Errorf("%w: bitcount start and end must be set together", ErrInvalidArgument)
+	}
+	if opts.Unit != "" && opts.Start == nil {
+		return 0, fmt.Errorf("%w: bitcount unit requires a range", ErrInvalidArgument)
+	}
+
+	var bc *redis.BitCount
+	if opts.Start != nil {
+		bc = &redis.BitCount{Start: *opts.Start, End: *opts.End}
+		switch opts.Unit {
+		case "":
+		case BitCountByte, BitCountBit:
+			bc.Unit = strings.ToUpper(string(opts.Unit))
+		default:
+			return 0, fmt.Errorf("%w: unknown bitcount unit %q", ErrInvalidArgument, opts.Unit)
+		}
+	}
+	ctx, cancel := c.readContext(ctx)
+	defer cancel()
+	return st.rdb.BitCount(ctx, key, bc).Result()
+}
+
+// maxBitOffset is the largest offset SETBIT accepts, as strings are
+// limited to 512MB
+const maxBitOffset = 1<<32 - 1
+
+// SetBit sets or clears the bit at offset in the string stored at key,
+// growing the string as needed
+func (c *Client) SetBit(ctx context.Context, key string, offset int64, value bool) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if err := checkBitOffset(offset); err != nil {
+		return err
+	}
+	bit := 0
+	if value {
+		bit = 1
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	return st.rdb.SetBit(ctx, key, offset, bit).Err()
+}
+
+// GetBit reports whether the bit at offset in the string stored at key is
+// set. Bits past the end of the string and in missing keys read as unset.
+func (c *Client) GetBit(ctx context.Context, key string, offset int64) (bool, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return false, ErrNilClient
+	}
+	if err := checkBitOffset(offset); err != nil {
+		return false, err
+	}
+	ctx, cancel := c.readContext(ctx)
+	defer cancel()
+	bit, err := st.rdb.GetBit(ctx, key, offset).Result()
+	if err != nil {
+		return false, err
+	}
+	return bit == 1, nil
+}
+
+// CountBits counts the set bits stored at key, as BitCount without a range
+func (c *Client) CountBits(ctx context.Context, key string) (int64, error) {
+	return c.BitCount(ctx, key, BitCountOptions{})
+}
+
+func checkBitOffset(offset int64) error {
+	if offset < 0 || offset > maxBitOffset {
+		return fmt.Errorf("%w: bit offset must be between 0 and %d", ErrInvalidArgument, int64(maxBitOffset))
+	}
+	return nil
+}
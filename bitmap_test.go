@@ -0,0 +1,173 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func int64Ptr(v int64) *int64 { return &v }
+
+// TestBitCount tests ranged and unranged bit counting
+func TestBitCount(t *testing.T) {
+	t.Run("invalid options", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.BitCount(context.Background(), "k", BitCountOptions{}); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+
+		// Options are validated before any command is sent
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		tests := []struct {
+			name string
+			opts BitCountOptions
+		}{
+			{"start without end", BitCountOptions{Start: int64Ptr(0)}},
+			{"end without start", BitCountOptions{End: int64Ptr(1)}},
+			{"unit without range", BitCountOptions{Unit: BitCountBit}},
+			{"unknown unit", BitCountOptions{Start: int64Ptr(0), End: int64Ptr(1), Unit: "word"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := client.BitCount(context.Background(), "rediskit:test:bits", tt.opts)
+				if !errors.Is(err, ErrInvalidArgument) {
+					t.Errorf("expected ErrInvalidArgument, got %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("with valid client", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+		key := "rediskit:test:bits"
+		defer client.Del(ctx, key)
+
+		// "\xff\x0f" has 8 bits set in the first byte and 4 in the second
+		if err := client.Set(ctx, key, "\xff\x0f", 0).Err(); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+
+		tests := []struct {
+			name string
+			opts BitCountOptions
+			want int64
+		}{
+			{"whole string", BitCountOptions{}, 12},
+			{"first byte", BitCountOptions{Start: int64Ptr(0), End: int64Ptr(0)}, 8},
+			{"last byte", BitCountOptions{Start: int64Ptr(-1), End: int64Ptr(-1)}, 4},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := client.BitCount(ctx, key, tt.opts)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("got %d, want %d", got, tt.want)
+				}
+			})
+		}
+
+		got, err := client.BitCount(ctx, "rediskit:test:bits:missing", BitCountOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != 0 {
+			t.Errorf("missing key: got %d, want 0", got)
+		}
+	})
+}
+
+// TestSetGetBit tests setting, reading back and counting single bits
+func TestSetGetBit(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		ctx := context.Background()
+		if err := client.SetBit(ctx, "k", 0, true); err != ErrNilClient {
+			t.Errorf("SetBit: expected ErrNilClient, got %v", err)
+		}
+		if _, err := client.GetBit(ctx, "k", 0); err != ErrNilClient {
+			t.Errorf("GetBit: expected ErrNilClient, got %v", err)
+		}
+		if _, err := client.CountBits(ctx, "k"); err != ErrNilClient {
+			t.Errorf("CountBits: expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid offsets", func(t *testing.T) {
+		// Offsets are validated before any command is sent
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		for _, offset := range []int64{-1, maxBitOffset + 1} {
+			if err := client.SetBit(context.Background(), "rediskit:test:bitmap", offset, true); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("SetBit(%d): expected ErrInvalidArgument, got %v", offset, err)
+			}
+			if _, err := client.GetBit(context.Background(), "rediskit:test:bitmap", offset); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("GetBit(%d): expected ErrInvalidArgument, got %v", offset, err)
+			}
+		}
+	})
+
+	t.Run("with valid client", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+		key := "rediskit:test:bitmap"
+		client.Del(ctx, key)
+		defer client.Del(ctx, key)
+
+		set := []int64{0, 7, 8, 100, 4096}
+		for _, offset := range set {
+			if err := client.SetBit(ctx, key, offset, true); err != nil {
+				t.Fatalf("SetBit(%d): %v", offset, err)
+			}
+		}
+		// Clearing a bit that was set, and one that never was
+		if err := client.SetBit(ctx, key, 100, false); err != nil {
+			t.Fatalf("SetBit: %v", err)
+		}
+		if err := client.SetBit(ctx, key, 50, false); err != nil {
+			t.Fatalf("SetBit: %v", err)
+		}
+
+		tests := []struct {
+			offset int64
+			want   bool
+		}{
+			{0, true},
+			{1, false},
+			{7, true},
+			{8, true},
+			{50, false},
+			{100, false},
+			{4096, true},
+			{1 << 20, false}, // past the end of the string
+		}
+		for _, tt := range tests {
+			got, err := client.GetBit(ctx, key, tt.offset)
+			if err != nil {
+				t.Fatalf("GetBit(%d): %v", tt.offset, err)
+			}
+			if got != tt.want {
+				t.Errorf("GetBit(%d) = %v, want %v", tt.offset, got, tt.want)
+			}
+		}
+
+		n, err := client.CountBits(ctx, key)
+		if err != nil {
+			t.Fatalf("CountBits: %v", err)
+		}
+		if n != 4 {
+			t.Errorf("CountBits = %d, want 4", n)
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package rediskit
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CircuitStatus is the state of a circuit breaker
+type CircuitStatus int
+
+const (
+	CircuitClosed   CircuitStatus = iota // requests flow normally
+	CircuitOpen                          // helpers fail fast with ErrCircuitOpen
+	CircuitHalfOpen                      // one probe request is let through
+)
+
+// CircuitScope decides how many circuit breakers a client keeps
+type CircuitScope string
+
+const (
+	CircuitScopeGlobal    CircuitScope = "global"    // one breaker, driven by StartHealthMonitor, the default
+	CircuitScopeOperation CircuitScope = "operation" // one breaker per JSON helper, driven by its own calls
+)
+
+// validCircuitScope reports whether s names a known scope
+func validCircuitScope(s CircuitScope) bool {
+	switch s {
+	case "", CircuitScopeGlobal, CircuitScopeOperation:
+		return true
+	}
+	return false
+}
+
+func (s CircuitStatus) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitState returns the state of the global circuit breaker. The
+// breaker is driven by StartHealthMonitor: it opens after CircuitThreshold
+// consecutive failed health checks and turns half-open once
+// CircuitCooldown has passed. It is always closed when CircuitThreshold is
+// 0, and with CircuitScopeOperation it only reports the health checks.
+func (c *Client) CircuitState() CircuitStatus {
+	st := c.load()
+	if st.config.CircuitThreshold <= 0 {
+		return CircuitClosed
+	}
+	return c.circuit.status(time.Now(), c.circuitCooldown())
+}
+
+// CircuitStates returns the state of every circuit breaker by scope, for
+// metrics. With CircuitScopeOperation the keys are the names of the JSON
+// helpers called so far, such as "GetJSON"; otherwise the only key is
+// "global". It is empty when CircuitThreshold is 0.
+func (c *Client) CircuitStates() map[string]CircuitStatus {
+	st := c.load()
+	states := make(map[string]CircuitStatus)
+	if st.config.CircuitThreshold <= 0 {
+		return states
+	}
+	now, cooldown := time.Now(), c.circuitCooldown()
+	if st.config.CircuitScope != CircuitScopeOperation {
+		states[string(CircuitScopeGlobal)] = c.circuit.status(now, cooldown)
+		return states
+	}
+	c.circuits.Range(func(op, b any) bool {
+		states[op.(string)] = b.(*circuitBreaker).status(now, cooldown)
+		return true
+	})
+	return states
+}
+
+// circuitGuard fails fast with ErrCircuitOpen while the circuit for op is
+// open or a half-open probe is already running. Otherwise the caller must
+// pass the result of its Redis call to done. With CircuitScopeOperation
+// every call of op feeds its breaker; the global breaker only learns from
+// probes, as health checks drive it.
+func (c *Client) circuitGuard(op string) (done func(error), err error) {
+	st := c.load()
+	threshold := st.config.CircuitThreshold
+	if threshold <= 0 {
+		return func(error) {}, nil
+	}
+	if st.config.CircuitScope == CircuitScopeOperation {
+		v, _ := c.circuits.LoadOrStore(op, new(circuitBreaker))
+		b := v.(*circuitBreaker)
+		if _, err := b.allow(time.Now(), c.circuitCooldown()); err != nil {
+			return func(error) {}, err
+		}
+		return func(err error) {
+			b.record(time.Now(), !isConnectionFailure(err), threshold)
+		}, nil
+	}
+	probe, err := c.circuit.allow(time.Now(), c.circuitCooldown())
+	if err != nil || !probe {
+		return func(error) {}, err
+	}
+	return func(err error) {
+		c.circuit.record(time.Now(), !isConnectionFailure(err), threshold)
+	}, nil
+}
+
+// circuitCooldown returns how long the circuit stays open before probing
+func (c *Client) circuitCooldown() time.Duration {
+	st := c.load()
+	if st.config.CircuitCooldown > 0 {
+		return st.config.CircuitCooldown
+	}
+	if st.config.HealthCheckInterval > 0 {
+		return st.config.HealthCheckInterval
+	}
+	return DefaultConfig().HealthCheckInterval
+}
+
+// isConnectionFailure reports whether err means Redis could not be reached,
+// as opposed to a miss or an error reply from a responsive server
+func isConnectionFailure(err error) bool {
+	var redisErr redis.Error
+	return err != nil && !errors.Is(err, redis.Nil) && !errors.As(err, &redisErr)
+}
+
+// circuitBreaker is the state machine behind CircuitState
+type circuitBreaker struct {
+	mu       sync.Mutex
+	state    CircuitStatus
+	failures int       // consecutive failures
+	openedAt time.Time // when the circuit last opened
+	probing  bool      // a half-open probe is in flight
+}
+
+// status returns the state at now, turning an open circuit half-open once
+// cooldown has passed
+func (b *circuitBreaker) status(now time.Time, cooldown time.Duration) CircuitStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.statusLocked(now, cooldown)
+}
+
+func (b *circuitBreaker) statusLocked(now time.Time, cooldown time.Duration) CircuitStatus {
+	if b.state == CircuitOpen && now.Sub(b.openedAt) >= cooldown {
+		b.state, b.probing = CircuitHalfOpen, false
+	}
+	return b.state
+}
+
+// allow reports whether a request may proceed, and whether it is the
+// half-open probe whose outcome decides the next state
+func (b *circuitBreaker) allow(now time.Time, cooldown time.Duration) (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.statusLocked(now, cooldown) {
+	case CircuitOpen:
+		return false, ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probing {
+			return false, ErrCircuitOpen
+		}
+		b.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// record feeds a health check or call outcome into the breaker. A success
+// closes the circuit; a failure reopens a half-open circuit, or opens a
+// closed one after threshold consecutive failures.
+func (b *circuitBreaker) record(now time.Time, ok bool, threshold int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if ok {
+		b.state, b.failures = CircuitClosed, 0
+		return
+	}
+	b.failures++
+	if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= threshold) {
+		b.state, b.openedAt = CircuitOpen, now
+	}
+}
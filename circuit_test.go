@@ -0,0 +1,234 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker tests the circuit breaker state machine
+func TestCircuitBreaker(t *testing.T) {
+	const threshold, cooldown = 3, time.Minute
+	start := time.Now()
+
+	type step struct {
+		at     time.Duration // since start
+		record *bool         // outcome to record, if any
+		allow  string        // "pass", "probe", or "reject" to call allow
+		want   CircuitStatus
+	}
+	ok, fail := true, false
+	steps := []step{
+		{record: &fail, want: CircuitClosed},
+		{record: &fail, want: CircuitClosed},
+		{record: &ok, want: CircuitClosed}, // a success resets the count
+		{record: &fail, want: CircuitClosed},
+		{record: &fail, want: CircuitClosed},
+		{allow: "pass", want: CircuitClosed},
+		{record: &fail, want: CircuitOpen},
+		{at: time.Second, allow: "reject", want: CircuitOpen},
+		{at: cooldown, want: CircuitHalfOpen},
+		{at: cooldown, allow: "probe", want: CircuitHalfOpen},
+		{at: cooldown, allow: "reject", want: CircuitHalfOpen}, // one probe at a time
+		{at: cooldown, record: &fail, want: CircuitOpen},       // failed probe reopens
+		{at: cooldown + time.Second, allow: "reject", want: CircuitOpen},
+		{at: 2 * cooldown, allow: "probe", want: CircuitHalfOpen},
+		{at: 2 * cooldown, record: &ok, want: CircuitClosed}, // recovery
+		{at: 2 * cooldown, allow: "pass", want: CircuitClosed},
+	}
+
+	var b circuitBreaker
+	for i, s := range steps {
+		now := start.Add(s.at)
+		if s.record != nil {
+			b.record(now, *s.record, threshold)
+		}
+		if s.allow != "" {
+			probe, err := b.allow(now, cooldown)
+			got := "pass"
+			if errors.Is(err, ErrCircuitOpen) {
+				got = "reject"
+			} else if probe {
+				got = "probe"
+			}
+			if got != s.allow {
+				t.Errorf("step %d: expected allow to %s, got %s", i, s.allow, got)
+			}
+		}
+		if got := b.status(now, cooldown); got != s.want {
+			t.Errorf("step %d: expected %v, got %v", i, s.want, got)
+		}
+	}
+}
+
+// TestCircuitStatusString tests circuit status names
+func TestCircuitStatusString(t *testing.T) {
+	for status, want := range map[CircuitStatus]string{
+		CircuitClosed:     "closed",
+		CircuitOpen:       "open",
+		CircuitHalfOpen:   "half-open",
+		CircuitStatus(42): "unknown",
+	} {
+		if got := status.String(); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
+
+// TestIsConnectionFailure tests which errors count against the circuit
+func TestIsConnectionFailure(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: false},
+		{err: ErrCacheMiss, want: false},
+		{err: serverError("WRONGTYPE wrong kind of value"), want: false},
+		{err: context.DeadlineExceeded, want: true},
+		{err: fmt.Errorf("dial tcp: %w", errors.New("connection refused")), want: true},
+	}
+	for _, tt := range tests {
+		if got := isConnectionFailure(tt.err); got != tt.want {
+			t.Errorf("%v: expected %v, got %v", tt.err, tt.want, got)
+		}
+	}
+}
+
+// TestClientCircuit tests that helpers fail fast while the circuit is open
+func TestClientCircuit(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		client.circuit.record(time.Now(), false, 1)
+		if got := client.CircuitState(); got != CircuitClosed {
+			t.Errorf("expected closed, got %v", got)
+		}
+	})
+
+	t.Run("health monitor opens the circuit", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Port = "1" // nothing listens here
+		cfg.MinIdleConns = 0
+		cfg.MaxRetries = -1
+		cfg.HealthCheckInterval = 5 * time.Millisecond
+		cfg.CircuitThreshold = 2
+		cfg.CircuitCooldown = time.Hour
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		client.StartHealthMonitor(ctx)
+		deadline := time.Now().Add(5 * time.Second)
+		for client.CircuitState() != CircuitOpen {
+			if time.Now().After(deadline) {
+				t.Fatal("timed out waiting for the circuit to open")
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		start := time.Now()
+		var v string
+		if err := client.GetJSON(context.Background(), "k", &v); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("GetJSON: expected ErrCircuitOpen, got %v", err)
+		}
+		if err := client.SetJSON(context.Background(), "k", "v", 0); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("SetJSON: expected ErrCircuitOpen, got %v", err)
+		}
+		if err := client.GetDelJSON(context.Background(), "k", &v); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("GetDelJSON: expected ErrCircuitOpen, got %v", err)
+		}
+		if err := client.GetSetJSON(context.Background(), "k", "v", &v); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("GetSetJSON: expected ErrCircuitOpen, got %v", err)
+		}
+		if err := client.MSetJSON(context.Background(), map[string]any{"k": "v"}, 0); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("MSetJSON: expected ErrCircuitOpen, got %v", err)
+		}
+		var vs []string
+		if _, err := client.MGetJSON(context.Background(), []string{"k"}, &vs); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("MGetJSON: expected ErrCircuitOpen, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+			t.Errorf("expected helpers to fail fast, took %v", elapsed)
+		}
+	})
+
+	t.Run("operation scope opens per helper", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Port = "1" // nothing listens here
+		cfg.MinIdleConns = 0
+		cfg.MaxRetries = -1
+		cfg.CircuitThreshold = 2
+		cfg.CircuitCooldown = time.Hour
+		cfg.CircuitScope = CircuitScopeOperation
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		var v string
+		for i := 0; i < cfg.CircuitThreshold; i++ {
+			if err := client.GetJSON(context.Background(), "k", &v); err == nil || errors.Is(err, ErrCircuitOpen) {
+				t.Fatalf("GetJSON %d: expected a connection error, got %v", i, err)
+			}
+		}
+		if err := client.GetJSON(context.Background(), "k", &v); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("GetJSON: expected ErrCircuitOpen, got %v", err)
+		}
+		if err := client.SetJSON(context.Background(), "k", "v", 0); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("SetJSON: expected a connection error, got %v", err)
+		}
+
+		want := map[string]CircuitStatus{"GetJSON": CircuitOpen, "SetJSON": CircuitClosed}
+		if got := client.CircuitStates(); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+		if got := client.CircuitState(); got != CircuitClosed {
+			t.Errorf("expected the global circuit to stay closed, got %v", got)
+		}
+	})
+
+	client := newTestClient(t)
+	cfg := *client.GetConfig()
+	cfg.CircuitThreshold = 1
+	cfg.CircuitCooldown = 20 * time.Millisecond
+	client, err := NewClient(&cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	t.Run("probe recovers the circuit", func(t *testing.T) {
+		client.circuit.record(time.Now(), false, cfg.CircuitThreshold)
+		if got := client.CircuitState(); got != CircuitOpen {
+			t.Fatalf("expected open, got %v", got)
+		}
+		time.Sleep(cfg.CircuitCooldown)
+		if got := client.CircuitState(); got != CircuitHalfOpen {
+			t.Fatalf("expected half-open, got %v", got)
+		}
+
+		// A miss from a responsive server is a successful probe
+		var v string
+		if err := client.GetJSON(context.Background(), "rediskit:test:circuit:missing", &v); !errors.Is(err, ErrCacheMiss) {
+			t.Fatalf("GetJSON: expected ErrCacheMiss, got %v", err)
+		}
+		if got := client.CircuitState(); got != CircuitClosed {
+			t.Errorf("expected closed after a successful probe, got %v", got)
+		}
+		want := map[string]CircuitStatus{"global": CircuitClosed}
+		if got := client.CircuitStates(); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+}
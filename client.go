@@ -2,24 +2,46 @@ package rediskit
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
-	ErrNilClient     = errors.New("redis client is nil")
-	ErrInvalidConfig = errors.New("invalid redis configuration")
+	ErrNilClient              = errors.New("redis client is nil")
+	ErrInvalidConfig          = errors.New("invalid redis configuration")
+	ErrConfigDisabled         = errors.New("redis CONFIG command is disabled")
+	ErrInvalidArgument        = errors.New("invalid argument")
+	ErrCacheMiss              = fmt.Errorf("cache miss: %w", redis.Nil)
+	ErrReplyTooLarge          = errors.New("redis reply exceeds max reply elements")
+	ErrKeyExists              = errors.New("redis key already exists")
+	ErrLockNotAcquired        = errors.New("redis lock is held by another owner")
+	ErrLockNotHeld            = errors.New("redis lock is no longer held")
+	ErrNotReplicated          = errors.New("redis write not acknowledged by enough replicas")
+	ErrDecryptFailed          = errors.New("redis value field decryption failed")
+	ErrComputeFailed          = errors.New("cached value computation failed")
+	ErrCircuitOpen            = errors.New("redis circuit breaker is open")
+	ErrQueueEmpty             = errors.New("redis queue is empty")
+	ErrMemoryUsageUnsupported = errors.New("redis MEMORY USAGE command is not supported")
+	ErrClientClosed           = errors.New("redis client is closed")
 )
 
 // Config holds Redis client configuration
 type Config struct {
 	Host                 string
 	Port                 string
+	Username             string // ACL user (Redis 6+), the default user when empty
 	Password             string
 	DB                   int
+	MaxDB                int      // Highest DB index the server allows, 0 for the Redis default of 15
+	KeyPrefix            string   // Namespace prepended to keys and patterns by helpers that support it
+	ReplicaAddrs         []string // host:port of read replicas used by ReadOnly
 	SocketKeepalive      bool
 	HealthCheckInterval  time.Duration
 	SocketTimeout        time.Duration
@@ -27,11 +49,81 @@ type Config struct {
 	MaxRetries           int
 	MinRetryBackoff      time.Duration
 	MaxRetryBackoff      time.Duration
+	RetryJitter          bool // Jitter the waits of WithRetry and other helper retries, not go-redis socket retries
 	PoolSize             int
 	MinIdleConns         int
 	ConnMaxIdleTime      time.Duration
 	ConnMaxLifetime      time.Duration
 	DefaultTimeout       time.Duration // Default timeout for operations
+	ReadTimeout          time.Duration // Timeout for read helpers such as GetJSON, DefaultTimeout when 0
+	WriteTimeout         time.Duration // Timeout for write helpers such as SetJSON, DefaultTimeout when 0
+	EnableTLS            bool          // Connect over TLS with a minimal TLS config
+	TLSServerName        string        // Server name to verify when EnableTLS is set, defaults to Host
+	TLSConfig            *tls.Config   // Full TLS config, enables TLS when set
+	MaxReplyElements     int           // Limit on elements returned by range helpers, 0 for unlimited
+	WarnDuplicateClients bool          // Log a warning when another live client targets the same address and DB
+	ServerClock          bool          // Use the Redis server's clock instead of the local one in time-based helpers
+	TrackPoolWait        bool          // Track connection acquisition waits for LastAcquireWait
+	EncryptionKey        []byte        // AES key (16, 24, or 32 bytes) for struct fields tagged rediskit:"encrypt"
+	TrackHitRatio        bool          // Sample the keyspace hit ratio from the health monitor for HitRatio
+	EnableTracing        bool          // Create an OpenTelemetry span for every command
+	TraceStatements      bool          // Record full commands, keys and arguments included, on spans
+	Compression          Compression   // Codec for large values stored by the JSON helpers, empty or "none" to disable
+	CompressionThreshold int           // Smallest encoded value to compress, in bytes, 0 for 1KB
+	CircuitThreshold     int           // Consecutive failures that open the circuit, 0 to disable
+	CircuitCooldown      time.Duration // How long the circuit stays open before a probe, 0 for HealthCheckInterval
+
+	// TracerProvider creates the spans when EnableTracing is set, defaulting
+	// to the global provider
+	TracerProvider trace.TracerProvider
+
+	// DecodeErrorPolicy decides what GetJSON and MGetJSON do with a stored
+	// value that fails to decode, DecodeErrorFail when empty
+	DecodeErrorPolicy DecodeErrorPolicy
+
+	// CircuitScope chooses between one circuit breaker for the whole client
+	// and one per JSON helper, CircuitScopeGlobal when empty
+	CircuitScope CircuitScope
+
+	// OptionsHook, when set, is called with the go-redis options NewClient
+	// built from this config just before the client is created, as an
+	// escape hatch for settings this package does not model. Fields it sets
+	// override the config-derived ones. Replicas get the same options with
+	// Addr then set to the replica, and failover and cluster clients take
+	// the fields their own options share with them.
+	OptionsHook func(*redis.Options)
+
+	// Logger receives internal events such as retries, health transitions,
+	// and reconnects; nil disables logging
+	Logger Logger
+
+	// Hooks are added to the go-redis client, and to replica clients, after
+	// this package's own hooks, e.g. SlowCommandHook
+	Hooks []redis.Hook
+
+	// OnPermissionDenied, when set, is called with the command name and error
+	// whenever a command fails with an ACL NOPERM error
+	OnPermissionDenied func(cmd string, err error)
+
+	// CommandTimeouts sets the deadline of individual commands, keyed by
+	// command name such as "blpop", when the caller's context has none.
+	// Socket reads are still bounded by SocketTimeout, except for blocking
+	// commands, whose read timeout go-redis extends by their block time.
+	CommandTimeouts map[string]time.Duration
+
+	// ConnectCommands are sent on every new connection after the handshake,
+	// e.g. {"CLIENT", "NO-EVICT", "on"}. A failing command fails the
+	// connection.
+	ConnectCommands [][]any
+
+	// OnConnect, when set, is called on every new connection after
+	// ClientName and ConnectCommands are applied. An error fails the
+	// connection and is returned by the command that needed it.
+	OnConnect func(ctx context.Context, cn *redis.Conn) error
+
+	// ClientName is set with CLIENT SETNAME on every new connection, so the
+	// connections can be told apart in CLIENT LIST
+	ClientName string
 }
 
 func DefaultConfig() *Config {
@@ -53,6 +145,9 @@ func DefaultConfig() *Config {
 	}
 }
 
+// defaultMaxDB is the highest DB index with Redis's default of 16 databases
+const defaultMaxDB = 15
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	if c.Host == "" {
@@ -61,19 +156,171 @@ func (c *Config) Validate() error {
 	if c.Port == "" {
 		return fmt.Errorf("%w: port is required", ErrInvalidConfig)
 	}
+	return c.validateShared()
+}
+
+// validateShared validates the pool, timeout, and feature settings that
+// every client kind shares, leaving out how the server is addressed
+func (c *Config) validateShared() error {
+	if c.MaxDB < 0 {
+		return fmt.Errorf("%w: max db must not be negative", ErrInvalidConfig)
+	}
+	maxDB := c.MaxDB
+	if maxDB == 0 {
+		maxDB = defaultMaxDB
+	}
+	if c.DB < 0 || c.DB > maxDB {
+		return fmt.Errorf("%w: db %d is out of range 0-%d", ErrInvalidConfig, c.DB, maxDB)
+	}
 	if c.PoolSize <= 0 {
 		return fmt.Errorf("%w: pool size must be greater than 0", ErrInvalidConfig)
 	}
 	if c.DefaultTimeout <= 0 {
 		return fmt.Errorf("%w: default timeout must be greater than 0", ErrInvalidConfig)
 	}
+	if c.ReadTimeout < 0 || c.WriteTimeout < 0 {
+		return fmt.Errorf("%w: read and write timeouts must not be negative", ErrInvalidConfig)
+	}
+	if c.MinIdleConns > c.PoolSize {
+		return fmt.Errorf("%w: min idle conns (%d) must not exceed pool size (%d)",
+			ErrInvalidConfig, c.MinIdleConns, c.PoolSize)
+	}
+	if c.MinRetryBackoff > 0 && c.MaxRetryBackoff > 0 && c.MinRetryBackoff > c.MaxRetryBackoff {
+		return fmt.Errorf("%w: min retry backoff (%s) must not exceed max retry backoff (%s)",
+			ErrInvalidConfig, c.MinRetryBackoff, c.MaxRetryBackoff)
+	}
+	if c.ConnMaxIdleTime > 0 && c.ConnMaxLifetime > 0 && c.ConnMaxIdleTime > c.ConnMaxLifetime {
+		return fmt.Errorf("%w: conn max idle time (%s) must not exceed conn max lifetime (%s)",
+			ErrInvalidConfig, c.ConnMaxIdleTime, c.ConnMaxLifetime)
+	}
+	if c.TLSConfig != nil && c.TLSServerName != "" && c.TLSConfig.ServerName != "" &&
+		c.TLSConfig.ServerName != c.TLSServerName {
+		return fmt.Errorf("%w: tls server name %q conflicts with TLSConfig server name %q",
+			ErrInvalidConfig, c.TLSServerName, c.TLSConfig.ServerName)
+	}
+	if c.MaxReplyElements < 0 {
+		return fmt.Errorf("%w: max reply elements must not be negative", ErrInvalidConfig)
+	}
+	switch len(c.EncryptionKey) {
+	case 0, 16, 24, 32:
+	default:
+		return fmt.Errorf("%w: encryption key must be 16, 24, or 32 bytes", ErrInvalidConfig)
+	}
+	for name, timeout := range c.CommandTimeouts {
+		if timeout <= 0 {
+			return fmt.Errorf("%w: timeout for command %q must be greater than 0", ErrInvalidConfig, name)
+		}
+	}
+	for i, args := range c.ConnectCommands {
+		if len(args) == 0 {
+			return fmt.Errorf("%w: connect command %d is empty", ErrInvalidConfig, i)
+		}
+	}
+	if !validCompression(c.Compression) {
+		return fmt.Errorf("%w: unknown compression %q", ErrInvalidConfig, c.Compression)
+	}
+	if c.CompressionThreshold < 0 {
+		return fmt.Errorf("%w: compression threshold must not be negative", ErrInvalidConfig)
+	}
+	if !validDecodeErrorPolicy(c.DecodeErrorPolicy) {
+		return fmt.Errorf("%w: unknown decode error policy %q", ErrInvalidConfig, c.DecodeErrorPolicy)
+	}
+	if c.CircuitThreshold < 0 {
+		return fmt.Errorf("%w: circuit threshold must not be negative", ErrInvalidConfig)
+	}
+	if c.CircuitCooldown < 0 {
+		return fmt.Errorf("%w: circuit cooldown must not be negative", ErrInvalidConfig)
+	}
+	if !validCircuitScope(c.CircuitScope) {
+		return fmt.Errorf("%w: unknown circuit scope %q", ErrInvalidConfig, c.CircuitScope)
+	}
+	for _, addr := range c.ReplicaAddrs {
+		if addr == "" {
+			return fmt.Errorf("%w: replica addresses must not be empty", ErrInvalidConfig)
+		}
+	}
 	return nil
 }
 
-// Client wraps redis.Client with additional functionality
+// tlsConfig returns the TLS config to connect with, or nil for plain TCP.
+// An explicit TLSConfig wins; EnableTLS alone builds a minimal one that
+// verifies TLSServerName, falling back to Host.
+func (c *Config) tlsConfig() *tls.Config {
+	serverName := c.TLSServerName
+	if c.TLSConfig != nil {
+		if serverName == "" || c.TLSConfig.ServerName != "" {
+			return c.TLSConfig
+		}
+		tlsCfg := c.TLSConfig.Clone()
+		tlsCfg.ServerName = serverName
+		return tlsCfg
+	}
+	if !c.EnableTLS {
+		return nil
+	}
+	if serverName == "" {
+		serverName = c.Host
+	}
+	return &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+}
+
+// Client wraps redis.Client with additional functionality. The embedded
+// redis.Client is the one current at construction or at the last
+// Reconfigure; calling its methods directly is not synchronized with
+// Reconfigure, unlike the helpers defined here.
 type Client struct {
 	*redis.Client
-	config *Config
+	state atomic.Pointer[clientState] // replaced by Reconfigure and RotatePassword
+
+	refreshing sync.Map // keys with a background refresh in flight
+	unregister func()   // releases the duplicate-client registration, if any; guarded by reconfigure
+
+	clockOffset   atomic.Int64 // server clock minus local clock, in nanoseconds
+	clockSyncedAt atomic.Int64 // unix nanoseconds of the last offset measurement
+
+	poolWait poolWaitTracker
+	inflight inflightTracker
+	hitRatio hitRatioTracker
+	circuit  circuitBreaker
+	circuits sync.Map // operation name to *circuitBreaker, for CircuitScopeOperation
+	retries  sync.Map // operation name to *retryCounter
+
+	background context.Context // cancelled when the client shuts down or closes
+	stop       context.CancelFunc
+	locks      sync.Map // *Lock held through this client, to its deadline in Unix nanoseconds
+
+	replicaNext atomic.Uint32 // round-robin position in replicas
+
+	reconfigure sync.Mutex // serializes Reconfigure
+	failover    bool       // created by NewFailoverClient
+	closed      bool       // set by Close and Shutdown, guarded by reconfigure
+
+	scripts     *ScriptRegistry
+	scriptsOnce sync.Once
+
+	hooks []redis.Hook // added with AddHook, guarded by reconfigure
+}
+
+// clientState is the part of a Client that Reconfigure and RotatePassword
+// replace. It is never modified once published, so a call that loads it
+// once sees a consistent client, config, and replicas throughout.
+type clientState struct {
+	rdb      *redis.Client
+	config   *Config
+	replicas []*redis.Client // clients for ReplicaAddrs
+	creds    *credentials    // nil for failover clients
+}
+
+// load returns the current state. A Client not built by a constructor has
+// no state and acts as a nil client with the default config.
+func (c *Client) load() *clientState {
+	if st := c.state.Load(); st != nil {
+		return st
+	}
+	return &clientState{config: DefaultConfig()}
 }
 
 // New creates a new Redis client with the given configuration
@@ -86,39 +333,159 @@ func NewClient(cfg *Config) (*Client, error) {
 		return nil, err
 	}
 
-	rdb := redis.NewClient(&redis.Options{
-		Addr:            cfg.Host + ":" + cfg.Port,
-		Password:        cfg.Password,
-		DB:              cfg.DB,
-		MaxRetries:      cfg.MaxRetries,
-		MinRetryBackoff: cfg.MinRetryBackoff,
-		MaxRetryBackoff: cfg.MaxRetryBackoff,
-		DialTimeout:     cfg.SocketConnectTimeout,
-		ReadTimeout:     cfg.SocketTimeout,
-		WriteTimeout:    cfg.SocketTimeout,
-		PoolSize:        cfg.PoolSize,
-		MinIdleConns:    cfg.MinIdleConns,
-		ConnMaxIdleTime: cfg.ConnMaxIdleTime,
-		ConnMaxLifetime: cfg.ConnMaxLifetime,
-	})
-
-	return &Client{
-		Client: rdb,
-		config: cfg,
-	}, nil
+	creds := newCredentials(cfg)
+	opts := cfg.options()
+	creds.install(opts)
+	rdb := redis.NewClient(opts)
+	if err := cfg.instrumentTracing(rdb); err != nil {
+		rdb.Close()
+		return nil, err
+	}
+
+	client := newClient(rdb, cfg, cfg.target())
+	replicas, err := client.newReplicas(cfg, creds)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	client.state.Store(&clientState{rdb: rdb, config: cfg, replicas: replicas, creds: creds})
+	return client, nil
+}
+
+// options converts cfg to go-redis client options, applying OptionsHook
+func (c *Config) options() *redis.Options {
+	opts := &redis.Options{
+		Addr:            c.Host + ":" + c.Port,
+		Username:        c.Username,
+		Password:        c.Password,
+		DB:              c.DB,
+		MaxRetries:      c.MaxRetries,
+		MinRetryBackoff: c.MinRetryBackoff,
+		MaxRetryBackoff: c.MaxRetryBackoff,
+		DialTimeout:     c.SocketConnectTimeout,
+		ReadTimeout:     c.SocketTimeout,
+		WriteTimeout:    c.SocketTimeout,
+		PoolSize:        c.PoolSize,
+		MinIdleConns:    c.MinIdleConns,
+		ConnMaxIdleTime: c.ConnMaxIdleTime,
+		ConnMaxLifetime: c.ConnMaxLifetime,
+		TLSConfig:       c.tlsConfig(),
+		OnConnect:       c.onConnect(),
+		ClientName:      c.ClientName,
+
+		ContextTimeoutEnabled: len(c.CommandTimeouts) > 0,
+	}
+	if c.OptionsHook != nil {
+		c.OptionsHook(opts)
+	}
+	return opts
+}
+
+// target identifies the server and DB of cfg for duplicate-client detection
+func (c *Config) target() string {
+	return fmt.Sprintf("%s:%s/%d", c.Host, c.Port, c.DB)
+}
+
+// newClient wraps rdb and installs the hooks and registrations cfg asks
+// for. target identifies the server for duplicate-client detection.
+func newClient(rdb *redis.Client, cfg *Config, target string) *Client {
+	client := &Client{Client: rdb}
+	client.state.Store(&clientState{rdb: rdb, config: cfg})
+	client.background, client.stop = context.WithCancel(context.Background())
+
+	client.installHooks(rdb, cfg)
+	if cfg.WarnDuplicateClients {
+		registerClient(client, target)
+	}
+	return client
+}
+
+// installHooks adds the hooks cfg asks for to rdb
+func (c *Client) installHooks(rdb *redis.Client, cfg *Config) {
+	rdb.AddHook(inflightHook{tracker: &c.inflight})
+	if len(cfg.CommandTimeouts) > 0 {
+		rdb.AddHook(newCommandTimeoutHook(cfg.CommandTimeouts))
+	}
+	if cfg.OnPermissionDenied != nil {
+		rdb.AddHook(permissionHook{onDenied: cfg.OnPermissionDenied})
+	}
+	if cfg.TrackPoolWait {
+		rdb.AddHook(poolWaitHook{rdb: rdb, tracker: &c.poolWait})
+	}
+	for _, h := range cfg.Hooks {
+		rdb.AddHook(h)
+	}
+}
+
+// Close closes the client and its connection pool
+func (c *Client) Close() error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	c.stopBackground()
+	c.markClosed()
+	// Reconfigure can no longer replace the state, so this is the last one
+	st = c.load()
+	closeReplicas(st.replicas)
+	return st.rdb.Close()
+}
+
+// markClosed makes later Reconfigure calls fail with ErrClientClosed and
+// releases the duplicate-client registration
+func (c *Client) markClosed() {
+	c.reconfigure.Lock()
+	c.closed = true
+	unregister := c.unregister
+	c.unregister = nil
+	c.reconfigure.Unlock()
+
+	if unregister != nil {
+		unregister()
+	}
 }
 
 // HealthCheck performs a health check on the Redis connection
 func (c *Client) HealthCheck() error {
-	if c.Client == nil {
+	return c.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext performs a health check bounded by ctx. DefaultTimeout
+// applies when ctx has no deadline. The call returns as soon as ctx is done,
+// even if the ping itself is still waiting on the socket.
+func (c *Client) HealthCheckContext(ctx context.Context) error {
+	st := c.load()
+	if st.rdb == nil {
 		return ErrNilClient
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), c.config.DefaultTimeout)
+	return healthCheck(ctx, st.rdb, st.config.DefaultTimeout)
+}
+
+// Pinger is the part of the go-redis API that health checks need. It is
+// satisfied by Client, ClusterClient, and the go-redis clients they wrap.
+type Pinger interface {
+	Ping(ctx context.Context) *redis.StatusCmd
+}
+
+// healthCheck pings p, applying timeout when ctx has no deadline
+func healthCheck(ctx context.Context, p Pinger, timeout time.Duration) error {
+	ctx, cancel := withTimeout(ctx, timeout)
 	defer cancel()
-	return c.Client.Ping(ctx).Err()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- p.Ping(ctx).Err()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // GetConfig returns the client configuration
 func (c *Client) GetConfig() *Config {
-	return c.config
+	return c.load().config
 }
@@ -1,9 +1,14 @@
 package rediskit
 
 import (
+	"context"
+	"errors"
+	"net"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/redis/go-redis/v9"
 )
 
 // TestDefaultConfig tests the default configuration
@@ -119,6 +124,103 @@ func TestConfigValidate(t *testing.T) {
 			wantErr:   true,
 			errString: "default timeout must be greater than 0",
 		},
+		{
+			name: "min idle conns above pool size",
+			config: &Config{
+				Host:           "localhost",
+				Port:           "6379",
+				PoolSize:       5,
+				MinIdleConns:   6,
+				DefaultTimeout: 5 * time.Second,
+			},
+			wantErr:   true,
+			errString: "min idle conns (6) must not exceed pool size (5)",
+		},
+		{
+			name: "min retry backoff above max",
+			config: &Config{
+				Host:            "localhost",
+				Port:            "6379",
+				PoolSize:        10,
+				DefaultTimeout:  5 * time.Second,
+				MinRetryBackoff: time.Second,
+				MaxRetryBackoff: 100 * time.Millisecond,
+			},
+			wantErr:   true,
+			errString: "min retry backoff (1s) must not exceed max retry backoff (100ms)",
+		},
+		{
+			name: "disabled max retry backoff",
+			config: &Config{
+				Host:            "localhost",
+				Port:            "6379",
+				PoolSize:        10,
+				DefaultTimeout:  5 * time.Second,
+				MinRetryBackoff: time.Second,
+				MaxRetryBackoff: -1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "conn max idle time above lifetime",
+			config: &Config{
+				Host:            "localhost",
+				Port:            "6379",
+				PoolSize:        10,
+				DefaultTimeout:  5 * time.Second,
+				ConnMaxIdleTime: time.Hour,
+				ConnMaxLifetime: time.Minute,
+			},
+			wantErr:   true,
+			errString: "conn max idle time (1h0m0s) must not exceed conn max lifetime (1m0s)",
+		},
+		{
+			name: "conn max idle time without lifetime",
+			config: &Config{
+				Host:            "localhost",
+				Port:            "6379",
+				PoolSize:        10,
+				DefaultTimeout:  5 * time.Second,
+				ConnMaxIdleTime: time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty replica address",
+			config: &Config{
+				Host:           "localhost",
+				Port:           "6379",
+				PoolSize:       10,
+				DefaultTimeout: 5 * time.Second,
+				ReplicaAddrs:   []string{"replica:6379", ""},
+			},
+			wantErr:   true,
+			errString: "replica addresses must not be empty",
+		},
+		{
+			name: "unknown compression",
+			config: &Config{
+				Host:           "localhost",
+				Port:           "6379",
+				PoolSize:       10,
+				DefaultTimeout: 5 * time.Second,
+				Compression:    "zstd",
+			},
+			wantErr:   true,
+			errString: `unknown compression "zstd"`,
+		},
+		{
+			name: "unknown circuit scope",
+			config: &Config{
+				Host:           "localhost",
+				Port:           "6379",
+				PoolSize:       10,
+				DefaultTimeout: 5 * time.Second,
+				CircuitScope:   "command",
+			},
+			wantErr:   true,
+			errString: `unknown circuit scope "command"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -141,6 +243,39 @@ func TestConfigValidate(t *testing.T) {
 	}
 }
 
+// TestConfigValidateDB tests the DB range check
+func TestConfigValidateDB(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      int
+		maxDB   int
+		wantErr bool
+	}{
+		{"negative", -1, 0, true},
+		{"zero", 0, 0, false},
+		{"default ceiling", 15, 0, false},
+		{"above default ceiling", 16, 0, true},
+		{"raised ceiling", 16, 63, false},
+		{"above raised ceiling", 64, 63, true},
+		{"negative ceiling", 0, -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.DB = tt.db
+			cfg.MaxDB = tt.maxDB
+			err := cfg.Validate()
+			if tt.wantErr && !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("expected ErrInvalidConfig, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 // TestNew tests client creation
 func TestNewClient(t *testing.T) {
 	t.Run("with nil config uses defaults", func(t *testing.T) {
@@ -211,6 +346,63 @@ func TestNewClient(t *testing.T) {
 			t.Errorf("expected 'host is required' error, got %v", err)
 		}
 	})
+
+	t.Run("options hook overrides derived options", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Host = "unreachable-host"
+		var seen string
+		cfg.OptionsHook = func(opts *redis.Options) {
+			seen = opts.Addr
+			opts.Addr = "127.0.0.1:6379"
+			opts.ClientName = "rediskit-test"
+		}
+
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if seen != "unreachable-host:6379" {
+			t.Errorf("expected the hook to see the config-derived addr, got %q", seen)
+		}
+		if opts := client.Options(); opts.Addr != "127.0.0.1:6379" || opts.ClientName != "rediskit-test" {
+			t.Errorf("expected the hook's changes to be used, got addr %q and name %q", opts.Addr, opts.ClientName)
+		}
+	})
+}
+
+// TestUsername tests passing ACL credentials to go-redis
+func TestUsername(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		password string
+	}{
+		{name: "username and password", username: "app", password: "secret"},
+		{name: "password only uses the default user", password: "secret"},
+		{name: "username only", username: "app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Username = tt.username
+			cfg.Password = tt.password
+			if err := cfg.Validate(); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+
+			client, err := NewClient(cfg)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			defer client.Close()
+			if opts := client.Options(); opts.Username != tt.username || opts.Password != tt.password {
+				t.Errorf("expected username %q and password %q, got %q and %q",
+					tt.username, tt.password, opts.Username, opts.Password)
+			}
+		})
+	}
 }
 
 // TestGetConfig tests getting configuration
@@ -250,7 +442,7 @@ func TestGetConfig(t *testing.T) {
 // TestHealthCheck tests health check functionality
 func TestHealthCheck(t *testing.T) {
 	t.Run("nil client returns error", func(t *testing.T) {
-		client := &Client{Client: nil, config: DefaultConfig()}
+		client := clientWith(nil, DefaultConfig())
 		err := client.HealthCheck()
 		if err != ErrNilClient {
 			t.Errorf("expected ErrNilClient, got %v", err)
@@ -274,6 +466,62 @@ func TestHealthCheck(t *testing.T) {
 	})
 }
 
+// TestHealthCheckContext tests that health checks honor caller cancellation
+func TestHealthCheckContext(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.HealthCheckContext(context.Background()); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("cancel mid-ping returns promptly", func(t *testing.T) {
+		// A server that accepts connections but never replies
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listen: %v", err)
+		}
+		defer ln.Close()
+		go func() {
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+			}
+		}()
+
+		cfg := DefaultConfig()
+		host, port, _ := net.SplitHostPort(ln.Addr().String())
+		cfg.Host = host
+		cfg.Port = port
+		cfg.MaxRetries = -1
+		cfg.DefaultTimeout = 10 * time.Second
+		cfg.SocketTimeout = 10 * time.Second
+
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		start := time.Now()
+		err = client.HealthCheckContext(ctx)
+		elapsed := time.Since(start)
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if elapsed > time.Second {
+			t.Errorf("health check took %v after cancellation", elapsed)
+		}
+	})
+}
+
 // TestClientEmbedding tests that client properly embeds redis.Client
 func TestClientEmbedding(t *testing.T) {
 	cfg := DefaultConfig()
@@ -414,3 +662,27 @@ func TestMultipleClients(t *testing.T) {
 		}
 	}
 }
+
+// newTestClient returns a client connected to a local Redis, skipping the
+// test when no server is reachable
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	client, err := NewClient(nil)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if err := client.HealthCheck(); err != nil {
+		client.Close()
+		t.Skip("Redis not available for testing")
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// clientWith returns a Client for rdb and cfg without the hooks and
+// registrations the constructors install
+func clientWith(rdb *redis.Client, cfg *Config) *Client {
+	client := &Client{Client: rdb}
+	client.state.Store(&clientState{rdb: rdb, config: cfg})
+	return client
+}
@@ -0,0 +1,48 @@
+package rediskit
+
+import (
+	"context"
+	"time"
+)
+
+// clockResync is how long a measured server clock offset is trusted
+const clockResync = time.Minute
+
+// ServerTime returns the Redis server's clock via TIME. Each call also
+// refreshes the cached offset between the server and local clocks.
+func (c *Client) ServerTime(ctx context.Context) (time.Time, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return time.Time{}, ErrNilClient
+	}
+	sent := time.Now()
+	serverNow, err := st.rdb.Time(ctx).Result()
+	if err != nil {
+		return time.Time{}, err
+	}
+	received := time.Now()
+
+	// Assume the server read its clock halfway through the round trip
+	local := sent.Add(received.Sub(sent) / 2)
+	c.clockOffset.Store(int64(serverNow.Sub(local)))
+	c.clockSyncedAt.Store(received.UnixNano())
+	return serverNow, nil
+}
+
+// now returns the current time for time-based helpers. With ServerClock
+// enabled it is the local clock shifted by the cached server offset,
+// resynchronized at most once per clockResync; if the server cannot be
+// reached, the last known offset is used.
+func (c *Client) now(ctx context.Context) time.Time {
+	st := c.load()
+	local := time.Now()
+	if !st.config.ServerClock {
+		return local
+	}
+	if local.Sub(time.Unix(0, c.clockSyncedAt.Load())) >= clockResync {
+		if serverNow, err := c.ServerTime(ctx); err == nil {
+			return serverNow
+		}
+	}
+	return local.Add(time.Duration(c.clockOffset.Load()))
+}
@@ -0,0 +1,67 @@
+package rediskit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestServerTime tests reading the server clock
+func TestServerTime(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.ServerTime(context.Background()); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("with valid client", func(t *testing.T) {
+		client := newTestClient(t)
+		got, err := client.ServerTime(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.IsZero() {
+			t.Error("expected a server time")
+		}
+		if client.clockSyncedAt.Load() == 0 {
+			t.Error("expected the clock offset to be recorded")
+		}
+	})
+}
+
+// TestNow tests choosing between the local and server clocks
+func TestNow(t *testing.T) {
+	t.Run("local clock by default", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		client.clockOffset.Store(int64(time.Hour))
+		if d := time.Until(client.now(context.Background())); d > time.Second || d < -time.Second {
+			t.Errorf("expected local time, got offset %v", d)
+		}
+	})
+
+	t.Run("cached server offset", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ServerClock = true
+		client := clientWith(nil, cfg)
+		client.clockOffset.Store(int64(time.Hour))
+		client.clockSyncedAt.Store(time.Now().UnixNano())
+
+		d := time.Until(client.now(context.Background()))
+		if d < 59*time.Minute || d > 61*time.Minute {
+			t.Errorf("expected the cached one hour offset, got %v", d)
+		}
+	})
+
+	t.Run("stale offset is used when the server is unreachable", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ServerClock = true
+		client := clientWith(nil, cfg)
+		client.clockOffset.Store(int64(-time.Hour))
+
+		d := time.Until(client.now(context.Background()))
+		if d > -59*time.Minute || d < -61*time.Minute {
+			t.Errorf("expected the last known offset, got %v", d)
+		}
+	})
+}
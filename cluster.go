@@ -0,0 +1,168 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ClusterConfig holds the configuration for a Redis Cluster client. The
+// embedded Config supplies credentials, pool, timeout, and retry settings;
+// its Host, Port, and DB are ignored since a cluster is addressed through
+// Addrs and only has DB 0. For TLS, set TLSServerName or TLSConfig since
+// there is no Host to verify.
+type ClusterConfig struct {
+	Config
+	Addrs []string // host:port seed addresses of cluster nodes
+}
+
+// IsClusterRedirect reports whether err is a MOVED or ASK redirect, which a
+// cluster node replies to a plain client asking for a key it does not
+// serve. Seeing one means the client should be a ClusterClient.
+func IsClusterRedirect(err error) bool {
+	var redisErr redis.Error
+	if !errors.As(err, &redisErr) {
+		return false
+	}
+	msg := redisErr.Error()
+	return strings.HasPrefix(msg, "MOVED ") || strings.HasPrefix(msg, "ASK ")
+}
+
+// Validate validates the cluster configuration
+func (c *ClusterConfig) Validate() error {
+	if len(c.Addrs) == 0 {
+		return fmt.Errorf("%w: at least one cluster address is required", ErrInvalidConfig)
+	}
+	for _, addr := range c.Addrs {
+		if addr == "" {
+			return fmt.Errorf("%w: cluster addresses must not be empty", ErrInvalidConfig)
+		}
+	}
+	if c.DB != 0 {
+		return fmt.Errorf("%w: redis cluster only supports DB 0", ErrInvalidConfig)
+	}
+	if len(c.ReplicaAddrs) > 0 {
+		return fmt.Errorf("%w: replica addresses are not supported for redis cluster", ErrInvalidConfig)
+	}
+	return c.Config.validateShared()
+}
+
+// ClusterClient wraps redis.ClusterClient with additional functionality
+type ClusterClient struct {
+	*redis.ClusterClient
+	config *Config
+
+	unregister func() // releases the duplicate-client registration, if any
+}
+
+// NewClusterClient creates a client for a Redis Cluster. The nodes in Addrs
+// seed slot discovery; the rest of the cluster is found from them.
+func NewClusterClient(cfg *ClusterConfig) (*ClusterClient, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: cluster config is required", ErrInvalidConfig)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	rdb := redis.NewClusterClient(cfg.clusterOptions())
+	if err := cfg.instrumentTracing(rdb); err != nil {
+		rdb.Close()
+		return nil, err
+	}
+	if len(cfg.CommandTimeouts) > 0 {
+		rdb.AddHook(newCommandTimeoutHook(cfg.CommandTimeouts))
+	}
+	if cfg.OnPermissionDenied != nil {
+		rdb.AddHook(permissionHook{onDenied: cfg.OnPermissionDenied})
+	}
+	for _, h := range cfg.Hooks {
+		rdb.AddHook(h)
+	}
+
+	client := &ClusterClient{
+		ClusterClient: rdb,
+		config:        &cfg.Config,
+	}
+	if cfg.WarnDuplicateClients {
+		client.unregister = registerTarget("cluster:"+strings.Join(cfg.Addrs, ","), cfg.Logger)
+	}
+	return client, nil
+}
+
+// clusterOptions converts cfg to go-redis cluster options. The settings
+// shared with a plain client come from Config.options, OptionsHook
+// included, and apply to the connection to every node.
+func (c *ClusterConfig) clusterOptions() *redis.ClusterOptions {
+	opts := c.Config.options()
+	return &redis.ClusterOptions{
+		Addrs: c.Addrs,
+
+		ClientName:                   opts.ClientName,
+		Dialer:                       opts.Dialer,
+		OnConnect:                    opts.OnConnect,
+		Protocol:                     opts.Protocol,
+		Username:                     opts.Username,
+		Password:                     opts.Password,
+		CredentialsProvider:          opts.CredentialsProvider,
+		CredentialsProviderContext:   opts.CredentialsProviderContext,
+		StreamingCredentialsProvider: opts.StreamingCredentialsProvider,
+		MaxRetries:                   opts.MaxRetries,
+		MinRetryBackoff:              opts.MinRetryBackoff,
+		MaxRetryBackoff:              opts.MaxRetryBackoff,
+		DialTimeout:                  opts.DialTimeout,
+		ReadTimeout:                  opts.ReadTimeout,
+		WriteTimeout:                 opts.WriteTimeout,
+		ContextTimeoutEnabled:        opts.ContextTimeoutEnabled,
+		PoolFIFO:                     opts.PoolFIFO,
+		PoolSize:                     opts.PoolSize,
+		PoolTimeout:                  opts.PoolTimeout,
+		MinIdleConns:                 opts.MinIdleConns,
+		MaxIdleConns:                 opts.MaxIdleConns,
+		MaxActiveConns:               opts.MaxActiveConns,
+		ConnMaxIdleTime:              opts.ConnMaxIdleTime,
+		ConnMaxLifetime:              opts.ConnMaxLifetime,
+		ReadBufferSize:               opts.ReadBufferSize,
+		WriteBufferSize:              opts.WriteBufferSize,
+		TLSConfig:                    opts.TLSConfig,
+		DisableIdentity:              opts.DisableIdentity,
+		IdentitySuffix:               opts.IdentitySuffix,
+		UnstableResp3:                opts.UnstableResp3,
+		PushNotificationProcessor:    opts.PushNotificationProcessor,
+		FailingTimeoutSeconds:        opts.FailingTimeoutSeconds,
+		MaintNotificationsConfig:     opts.MaintNotificationsConfig,
+	}
+}
+
+// Close closes the client and the connection pools of every node
+func (c *ClusterClient) Close() error {
+	if c.ClusterClient == nil {
+		return ErrNilClient
+	}
+	if c.unregister != nil {
+		c.unregister()
+	}
+	return c.ClusterClient.Close()
+}
+
+// HealthCheck performs a health check on the cluster connection
+func (c *ClusterClient) HealthCheck() error {
+	return c.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext performs a health check bounded by ctx, with the same
+// timeout handling as Client.HealthCheckContext
+func (c *ClusterClient) HealthCheckContext(ctx context.Context) error {
+	if c.ClusterClient == nil {
+		return ErrNilClient
+	}
+	return healthCheck(ctx, c.ClusterClient, c.config.DefaultTimeout)
+}
+
+// GetConfig returns the client configuration
+func (c *ClusterClient) GetConfig() *Config {
+	return c.config
+}
@@ -0,0 +1,167 @@
+package rediskit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func testClusterConfig() *ClusterConfig {
+	return &ClusterConfig{
+		Config: *DefaultConfig(),
+		Addrs:  []string{"127.0.0.1:1", "127.0.0.1:2"},
+	}
+}
+
+// TestClusterConfigValidate tests cluster configuration validation
+func TestClusterConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(c *ClusterConfig)
+		errString string
+	}{
+		{name: "valid config", mutate: func(c *ClusterConfig) {}},
+		{name: "host and port are not required", mutate: func(c *ClusterConfig) { c.Host, c.Port = "", "" }},
+		{name: "no addresses", mutate: func(c *ClusterConfig) { c.Addrs = nil }, errString: "at least one cluster address is required"},
+		{name: "empty address", mutate: func(c *ClusterConfig) { c.Addrs = []string{""} }, errString: "cluster addresses must not be empty"},
+		{name: "non-zero DB", mutate: func(c *ClusterConfig) { c.DB = 1 }, errString: "only supports DB 0"},
+		{name: "replica addresses", mutate: func(c *ClusterConfig) { c.ReplicaAddrs = []string{"replica:6379"} }, errString: "replica addresses are not supported"},
+		{name: "shared settings", mutate: func(c *ClusterConfig) { c.DefaultTimeout = 0 }, errString: "default timeout must be greater than 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testClusterConfig()
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.errString == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("expected ErrInvalidConfig, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.errString) {
+				t.Errorf("expected error to contain %q, got %q", tt.errString, err.Error())
+			}
+		})
+	}
+}
+
+// TestNewClusterClient tests cluster client creation
+func TestNewClusterClient(t *testing.T) {
+	t.Run("nil config returns error", func(t *testing.T) {
+		if _, err := NewClusterClient(nil); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	t.Run("invalid config returns error", func(t *testing.T) {
+		cfg := testClusterConfig()
+		cfg.Addrs = nil
+		client, err := NewClusterClient(cfg)
+		if err == nil {
+			t.Error("expected error for invalid config")
+		}
+		if client != nil {
+			t.Error("expected nil client for invalid config")
+		}
+	})
+
+	t.Run("applies config", func(t *testing.T) {
+		cfg := testClusterConfig()
+		cfg.PoolSize = 7
+		cfg.MaxRetries = -1
+		cfg.DefaultTimeout = 200 * time.Millisecond
+		client, err := NewClusterClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClusterClient: %v", err)
+		}
+		defer client.Close()
+
+		if got := client.GetConfig(); got.PoolSize != 7 {
+			t.Errorf("GetConfig: expected pool size 7, got %d", got.PoolSize)
+		}
+		if got := client.Options(); got.PoolSize != 7 || len(got.Addrs) != 2 {
+			t.Errorf("unexpected cluster options: pool size %d, addrs %v", got.PoolSize, got.Addrs)
+		}
+
+		// No node is listening, so the health check must fail
+		if err := client.HealthCheck(); err == nil {
+			t.Error("expected health check to fail without cluster nodes")
+		}
+	})
+
+	t.Run("options match a regular client", func(t *testing.T) {
+		cfg := testClusterConfig()
+		cfg.EnableTLS = true
+		cfg.TLSServerName = "redis.internal"
+		cfg.ConnectCommands = [][]any{{"client", "no-evict", "on"}}
+		cfg.OptionsHook = func(opts *redis.Options) {
+			opts.PoolTimeout = 3 * time.Second
+			opts.MaxActiveConns = 20
+			opts.ClientName = "checkout-service"
+		}
+
+		opts := cfg.clusterOptions()
+		if len(opts.Addrs) != len(cfg.Addrs) {
+			t.Errorf("expected addrs %v, got %v", cfg.Addrs, opts.Addrs)
+		}
+		if opts.PoolTimeout != 3*time.Second || opts.MaxActiveConns != 20 || opts.ClientName != "checkout-service" {
+			t.Errorf("expected OptionsHook settings, got pool timeout %v, max active %d, name %q",
+				opts.PoolTimeout, opts.MaxActiveConns, opts.ClientName)
+		}
+		if opts.TLSConfig == nil || opts.TLSConfig.ServerName != "redis.internal" {
+			t.Errorf("expected TLS for redis.internal, got %+v", opts.TLSConfig)
+		}
+		if opts.OnConnect == nil {
+			t.Error("expected OnConnect to send ConnectCommands")
+		}
+	})
+
+	t.Run("nil client", func(t *testing.T) {
+		client := &ClusterClient{config: DefaultConfig()}
+		if err := client.HealthCheck(); !errors.Is(err, ErrNilClient) {
+			t.Errorf("HealthCheck: expected ErrNilClient, got %v", err)
+		}
+		if err := client.Close(); !errors.Is(err, ErrNilClient) {
+			t.Errorf("Close: expected ErrNilClient, got %v", err)
+		}
+	})
+}
+
+// TestPinger checks that every client kind can be health checked through
+// the shared interface
+func TestPinger(t *testing.T) {
+	var _ Pinger = (*Client)(nil)
+	var _ Pinger = (*ClusterClient)(nil)
+}
+
+// TestIsClusterRedirect tests detecting cluster redirects
+func TestIsClusterRedirect(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"moved", serverError("MOVED 3999 127.0.0.1:6381"), true},
+		{"ask", serverError("ASK 3999 127.0.0.1:6381"), true},
+		{"wrapped moved", fmt.Errorf("get: %w", serverError("MOVED 3999 127.0.0.1:6381")), true},
+		{"other server error", serverError("ERR unknown command"), false},
+		{"plain error with moved text", errors.New("MOVED 3999 127.0.0.1:6381"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsClusterRedirect(tt.err); got != tt.want {
+				t.Errorf("IsClusterRedirect(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
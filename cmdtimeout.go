@@ -0,0 +1,46 @@
+package rediskit
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// commandTimeoutHook applies per-command deadlines from
+// Config.CommandTimeouts
+type commandTimeoutHook struct {
+	timeouts map[string]time.Duration // keyed by lowercase command name
+}
+
+func newCommandTimeoutHook(timeouts map[string]time.Duration) commandTimeoutHook {
+	h := commandTimeoutHook{timeouts: make(map[string]time.Duration, len(timeouts))}
+	for name, timeout := range timeouts {
+		h.timeouts[strings.ToLower(name)] = timeout
+	}
+	return h
+}
+
+func (h commandTimeoutHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h commandTimeoutHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		timeout, ok := h.timeouts[cmd.Name()]
+		if !ok {
+			return next(ctx, cmd)
+		}
+		if _, ok := ctx.Deadline(); ok {
+			return next(ctx, cmd)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return next(ctx, cmd)
+	}
+}
+
+func (h commandTimeoutHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
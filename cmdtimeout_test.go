@@ -0,0 +1,101 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestCommandTimeouts tests per-command deadline overrides
+func TestCommandTimeouts(t *testing.T) {
+	t.Run("validation", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.CommandTimeouts = map[string]time.Duration{"blpop": 0}
+		if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	t.Run("hook sets deadlines", func(t *testing.T) {
+		hook := newCommandTimeoutHook(map[string]time.Duration{"BLPOP": time.Minute})
+
+		var deadline time.Time
+		var hasDeadline bool
+		process := hook.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error {
+			deadline, hasDeadline = ctx.Deadline()
+			return nil
+		})
+
+		tests := []struct {
+			name         string
+			ctx          func() (context.Context, context.CancelFunc)
+			cmd          redis.Cmder
+			wantDeadline time.Duration // 0 for none
+		}{
+			{
+				name:         "configured command",
+				ctx:          func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+				cmd:          redis.NewStringSliceCmd(context.Background(), "blpop", "q", 0),
+				wantDeadline: time.Minute,
+			},
+			{
+				name: "other command",
+				ctx:  func() (context.Context, context.CancelFunc) { return context.Background(), func() {} },
+				cmd:  redis.NewStringCmd(context.Background(), "get", "k"),
+			},
+			{
+				name: "caller deadline wins",
+				ctx: func() (context.Context, context.CancelFunc) {
+					return context.WithTimeout(context.Background(), time.Second)
+				},
+				cmd:          redis.NewStringSliceCmd(context.Background(), "blpop", "q", 0),
+				wantDeadline: time.Second,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx, cancel := tt.ctx()
+				defer cancel()
+				process(ctx, tt.cmd)
+
+				if tt.wantDeadline == 0 {
+					if hasDeadline {
+						t.Errorf("expected no deadline, got one in %v", time.Until(deadline))
+					}
+					return
+				}
+				if !hasDeadline {
+					t.Fatal("expected a deadline")
+				}
+				if left := time.Until(deadline); left > tt.wantDeadline || left < tt.wantDeadline-time.Second {
+					t.Errorf("expected a deadline about %v away, got %v", tt.wantDeadline, left)
+				}
+			})
+		}
+	})
+
+	t.Run("deadline cuts a blocking command short", func(t *testing.T) {
+		newTestClient(t) // skips without Redis
+		cfg := DefaultConfig()
+		cfg.CommandTimeouts = map[string]time.Duration{"blpop": 50 * time.Millisecond}
+		cfg.MaxRetries = -1
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		start := time.Now()
+		err = client.BLPop(context.Background(), 10*time.Second, "rediskit:test:cmdtimeout:empty").Err()
+		if err == nil || err == redis.Nil {
+			t.Fatalf("expected a timeout error, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("expected the override to cut BLPOP short, took %v", elapsed)
+		}
+	})
+}
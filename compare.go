@@ -0,0 +1,29 @@
+package rediskit
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// deleteIfEqualsScript deletes KEYS[1] only if it holds ARGV[1]
+var deleteIfEqualsScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// DeleteIfEquals atomically deletes key only if it still holds expected,
+// returning whether it was deleted. A missing key never matches.
+func (c *Client) DeleteIfEquals(ctx context.Context, key, expected string) (bool, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return false, ErrNilClient
+	}
+	n, err := deleteIfEqualsScript.Run(ctx, st.rdb, []string{key}, expected).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
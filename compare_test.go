@@ -0,0 +1,58 @@
+package rediskit
+
+import (
+	"context"
+	"testing"
+)
+
+// TestDeleteIfEquals tests compare-and-delete
+func TestDeleteIfEquals(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.DeleteIfEquals(context.Background(), "k", "v"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:delete-if-equals"
+	defer client.Del(ctx, key)
+
+	tests := []struct {
+		name        string
+		stored      string // empty for a missing key
+		expected    string
+		wantDeleted bool
+	}{
+		{name: "matching value is deleted", stored: "mine", expected: "mine", wantDeleted: true},
+		{name: "mismatched value is kept", stored: "theirs", expected: "mine"},
+		{name: "missing key", expected: "mine"},
+		{name: "empty expected does not match missing key", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.Del(ctx, key)
+			if tt.stored != "" {
+				client.Set(ctx, key, tt.stored, 0)
+			}
+
+			deleted, err := client.DeleteIfEquals(ctx, key, tt.expected)
+			if err != nil {
+				t.Fatalf("DeleteIfEquals: %v", err)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("expected deleted=%v, got %v", tt.wantDeleted, deleted)
+			}
+
+			want := tt.stored
+			if tt.wantDeleted {
+				want = ""
+			}
+			if v := client.Get(ctx, key).Val(); v != want {
+				t.Errorf("expected stored value %q afterwards, got %q", want, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package rediskit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+)
+
+// Compression selects the codec for values stored through the JSON helpers
+type Compression string
+
+const (
+	CompressionNone   Compression = "none"
+	CompressionGzip   Compression = "gzip"
+	CompressionSnappy Compression = "snappy"
+)
+
+// defaultCompressionThreshold is the smallest payload compressed when
+// CompressionThreshold is 0
+const defaultCompressionThreshold = 1024
+
+// compressedMagic starts every compressed value and is followed by a codec
+// byte. JSON never starts with a NUL byte, so plain values are told apart.
+var compressedMagic = []byte("\x00rk")
+
+const (
+	codecGzip   byte = 1
+	codecSnappy byte = 2
+)
+
+// validCompression reports whether c names a known codec
+func validCompression(c Compression) bool {
+	switch c {
+	case "", CompressionNone, CompressionGzip, CompressionSnappy:
+		return true
+	}
+	return false
+}
+
+// compress encodes data with the configured codec when it reaches the
+// threshold, prefixing it with compressedMagic and the codec byte
+func (c *Client) compress(data []byte) ([]byte, error) {
+	st := c.load()
+	threshold := st.config.CompressionThreshold
+	if threshold == 0 {
+		threshold = defaultCompressionThreshold
+	}
+	if len(data) < threshold {
+		return data, nil
+	}
+
+	header := len(compressedMagic) + 1
+	switch st.config.Compression {
+	case CompressionGzip:
+		var buf bytes.Buffer
+		buf.Write(compressedMagic)
+		buf.WriteByte(codecGzip)
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionSnappy:
+		out := make([]byte, header, header+snappy.MaxEncodedLen(len(data)))
+		copy(out, compressedMagic)
+		out[header-1] = codecSnappy
+		return append(out, snappy.Encode(nil, data)...), nil
+	}
+	return data, nil
+}
+
+// decompress reverses compress. Values without the header are returned
+// unchanged, and every codec is understood whatever Compression is set to,
+// so values written under an earlier setting stay readable.
+func decompress(data []byte) ([]byte, error) {
+	header := len(compressedMagic) + 1
+	if len(data) < header || !bytes.HasPrefix(data, compressedMagic) {
+		return data, nil
+	}
+
+	body := data[header:]
+	switch codec := data[header-1]; codec {
+	case codecGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		return io.ReadAll(zr)
+	case codecSnappy:
+		return snappy.Decode(nil, body)
+	default:
+		return nil, fmt.Errorf("unknown compression codec %d", codec)
+	}
+}
@@ -0,0 +1,119 @@
+package rediskit
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCompress tests compressing and decompressing values
+func TestCompress(t *testing.T) {
+	large := []byte(`{"data":"` + strings.Repeat("abcdefgh", 256) + `"}`)
+	small := []byte(`{"data":"tiny"}`)
+
+	tests := []struct {
+		name       string
+		codec      Compression
+		threshold  int
+		input      []byte
+		compressed bool
+	}{
+		{name: "gzip", codec: CompressionGzip, input: large, compressed: true},
+		{name: "snappy", codec: CompressionSnappy, input: large, compressed: true},
+		{name: "gzip below threshold", codec: CompressionGzip, input: small},
+		{name: "snappy below threshold", codec: CompressionSnappy, input: small},
+		{name: "custom threshold", codec: CompressionSnappy, threshold: 8, input: small, compressed: true},
+		{name: "none", codec: CompressionNone, input: large},
+		{name: "unset", input: large},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Compression = tt.codec
+			cfg.CompressionThreshold = tt.threshold
+			client := clientWith(nil, cfg)
+
+			stored, err := client.compress(tt.input)
+			if err != nil {
+				t.Fatalf("compress: %v", err)
+			}
+			if got := bytes.HasPrefix(stored, compressedMagic); got != tt.compressed {
+				t.Errorf("expected compressed=%v, got %v", tt.compressed, got)
+			}
+			if !tt.compressed && !bytes.Equal(stored, tt.input) {
+				t.Error("expected the value to pass through unchanged")
+			}
+			if tt.compressed && len(stored) >= len(tt.input) && len(tt.input) > 100 {
+				t.Errorf("expected a smaller value, got %d bytes from %d", len(stored), len(tt.input))
+			}
+
+			got, err := decompress(stored)
+			if err != nil {
+				t.Fatalf("decompress: %v", err)
+			}
+			if !bytes.Equal(got, tt.input) {
+				t.Errorf("round trip mismatch: got %q", got)
+			}
+		})
+	}
+
+	t.Run("unknown codec", func(t *testing.T) {
+		data := append(append([]byte{}, compressedMagic...), 99, 'x')
+		if _, err := decompress(data); err == nil {
+			t.Error("expected an error for an unknown codec")
+		}
+	})
+}
+
+// TestJSONCompression tests that the JSON helpers compress transparently
+func TestJSONCompression(t *testing.T) {
+	plain := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:json:compressed"
+	defer plain.Del(ctx, key)
+
+	value := map[string]string{"data": strings.Repeat("compressible ", 200)}
+
+	// Written before compression was enabled
+	if err := plain.SetJSON(ctx, key, value, time.Minute); err != nil {
+		t.Fatalf("SetJSON: %v", err)
+	}
+
+	for _, codec := range []Compression{CompressionGzip, CompressionSnappy} {
+		t.Run(string(codec), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Compression = codec
+			client, err := NewClient(cfg)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			defer client.Close()
+
+			var got map[string]string
+			if err := client.GetJSON(ctx, key, &got); err != nil || got["data"] != value["data"] {
+				t.Fatalf("expected to read an uncompressed value, got %v", err)
+			}
+
+			if err := client.SetJSON(ctx, key, value, time.Minute); err != nil {
+				t.Fatalf("SetJSON: %v", err)
+			}
+			raw, _ := plain.Get(ctx, key).Bytes()
+			if !bytes.HasPrefix(raw, compressedMagic) {
+				t.Error("expected the stored value to be compressed")
+			}
+
+			// Readable by any client, whatever its own setting
+			got = nil
+			if err := plain.GetJSON(ctx, key, &got); err != nil || got["data"] != value["data"] {
+				t.Errorf("GetJSON: expected a round trip, got %v", err)
+			}
+			var many []map[string]string
+			if _, err := plain.MGetJSON(ctx, []string{key}, &many); err != nil || many[0]["data"] != value["data"] {
+				t.Errorf("MGetJSON: expected a round trip, got %v", err)
+			}
+		})
+	}
+}
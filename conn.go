@@ -0,0 +1,116 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Reset returns a borrowed connection to a clean state with RESET (Redis
+// 6.2+), discarding any pending MULTI, watched keys, and subscriptions.
+// Because RESET also drops authentication, the selected DB, the protocol
+// version, and connection settings, those are restored from the client
+// options, ConnectCommands, and OnConnect afterwards.
+// On servers without RESET, any pending transaction and watches are
+// discarded instead. Transaction calls Reset when an attempt fails;
+// subscriptions hold their own connection and close it instead.
+func (c *Client) Reset(ctx context.Context, cn *redis.Conn) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if cn == nil {
+		return fmt.Errorf("%w: connection is nil", ErrInvalidArgument)
+	}
+
+	err := cn.Do(ctx, "reset").Err()
+	if isUnknownCommand(err) {
+		return resetFallback(ctx, cn)
+	}
+	if err != nil {
+		return err
+	}
+	return c.restoreConnState(ctx, cn)
+}
+
+// resetFallback approximates RESET on servers that lack it
+func resetFallback(ctx context.Context, cn *redis.Conn) error {
+	if err := cn.Do(ctx, "discard").Err(); err != nil && !strings.Contains(err.Error(), "without MULTI") {
+		return err
+	}
+	return cn.Do(ctx, "unwatch").Err()
+}
+
+// restoreConnState re-applies the per-connection state RESET clears
+func (c *Client) restoreConnState(ctx context.Context, cn *redis.Conn) error {
+	st := c.load()
+	opt := st.rdb.Options()
+	username, password := opt.Username, opt.Password
+	if opt.CredentialsProvider != nil {
+		username, password = opt.CredentialsProvider()
+	}
+	if password != "" && username == "" {
+		username = "default"
+	}
+
+	switch {
+	case opt.Protocol == 3:
+		if err := cn.Hello(ctx, 3, username, password, "").Err(); err != nil {
+			return err
+		}
+	case password != "":
+		if err := cn.AuthACL(ctx, username, password).Err(); err != nil {
+			return err
+		}
+	}
+
+	if opt.DB != 0 {
+		if err := cn.Select(ctx, opt.DB).Err(); err != nil {
+			return err
+		}
+	}
+	if opt.ClientName != "" {
+		if err := cn.ClientSetName(ctx, opt.ClientName).Err(); err != nil {
+			return err
+		}
+	}
+	if opt.OnConnect != nil {
+		return opt.OnConnect(ctx, cn)
+	}
+	return nil
+}
+
+// onConnect returns the OnConnect callback that sends ConnectCommands and
+// then calls Config.OnConnect, or nil when there is nothing to do
+func (c *Config) onConnect() func(ctx context.Context, cn *redis.Conn) error {
+	if len(c.ConnectCommands) == 0 && c.OnConnect == nil {
+		return nil
+	}
+	cmds, hook := c.ConnectCommands, c.OnConnect
+	return func(ctx context.Context, cn *redis.Conn) error {
+		// The connection may be set up for a command Shutdown already
+		// admitted, so its setup commands must not be refused
+		ctx = context.WithValue(ctx, inflightKey{}, true)
+		if err := runConnectCommands(ctx, cn, cmds); err != nil {
+			return err
+		}
+		if hook != nil {
+			if err := hook(ctx, cn); err != nil {
+				return fmt.Errorf("on connect: %w", err)
+			}
+		}
+		return nil
+	}
+}
+
+// runConnectCommands sends each command on cn, stopping at the first failure
+func runConnectCommands(ctx context.Context, cn *redis.Conn, cmds [][]any) error {
+	for _, args := range cmds {
+		if err := cn.Do(ctx, args...).Err(); err != nil {
+			return fmt.Errorf("connect command %v: %w", args, err)
+		}
+	}
+	return nil
+}
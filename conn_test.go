@@ -0,0 +1,193 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestReset tests returning a connection to a clean state
+func TestReset(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.Reset(context.Background(), nil); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("nil connection returns error", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if err := client.Reset(context.Background(), nil); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("clears a pending transaction", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+		key := "rediskit:test:reset"
+		defer client.Del(ctx, key)
+
+		cn := client.Conn()
+		defer cn.Close()
+
+		if err := cn.Do(ctx, "multi").Err(); err != nil {
+			t.Fatalf("MULTI: %v", err)
+		}
+		if err := cn.Do(ctx, "set", key, "queued").Err(); err != nil {
+			t.Fatalf("SET: %v", err)
+		}
+
+		if err := client.Reset(ctx, cn); err != nil {
+			t.Fatalf("Reset: %v", err)
+		}
+
+		// Outside a transaction a command executes immediately
+		if err := cn.Set(ctx, key, "direct", 0).Err(); err != nil {
+			t.Fatalf("SET after reset: %v", err)
+		}
+		if v := client.Get(ctx, key).Val(); v != "direct" {
+			t.Errorf("expected direct write, got %q", v)
+		}
+	})
+}
+
+// TestConnectCommands tests sending setup commands on new connections
+func TestConnectCommands(t *testing.T) {
+	newClient := func(t *testing.T, cmds [][]any) *Client {
+		newTestClient(t) // skips without Redis
+		cfg := DefaultConfig()
+		cfg.MaxRetries = -1
+		cfg.ConnectCommands = cmds
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+
+	t.Run("empty command is rejected", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ConnectCommands = [][]any{{"client", "setname", "x"}, {}}
+		if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	t.Run("commands run on connect", func(t *testing.T) {
+		client := newClient(t, [][]any{{"client", "setname", "rediskit-test"}})
+		name, err := client.ClientGetName(context.Background()).Result()
+		if err != nil {
+			t.Fatalf("CLIENT GETNAME: %v", err)
+		}
+		if name != "rediskit-test" {
+			t.Errorf("expected client name rediskit-test, got %q", name)
+		}
+	})
+
+	t.Run("failing command fails the connection", func(t *testing.T) {
+		client := newClient(t, [][]any{{"rediskit-no-such-command"}})
+		err := client.Ping(context.Background()).Err()
+		if err == nil {
+			t.Fatal("expected ping to fail")
+		}
+		if !strings.Contains(err.Error(), "rediskit-no-such-command") {
+			t.Errorf("expected error to name the failing command, got %v", err)
+		}
+	})
+}
+
+// TestOnConnect tests the per-connection setup hook and client name
+func TestOnConnect(t *testing.T) {
+	newClient := func(t *testing.T, cfg *Config) *Client {
+		newTestClient(t) // skips without Redis
+		cfg.MaxRetries = -1
+		cfg.MinIdleConns = 0
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+	ctx := context.Background()
+
+	t.Run("hook runs on every new connection", func(t *testing.T) {
+		var calls atomic.Int32
+		cfg := DefaultConfig()
+		cfg.OnConnect = func(ctx context.Context, cn *redis.Conn) error {
+			calls.Add(1)
+			return nil
+		}
+		client := newClient(t, cfg)
+		before := calls.Load()
+
+		// Holding one connection forces the next command onto a new one
+		cn := client.Conn()
+		defer cn.Close()
+		if err := cn.Ping(ctx).Err(); err != nil {
+			t.Fatalf("PING on held conn: %v", err)
+		}
+		if err := client.Ping(ctx).Err(); err != nil {
+			t.Fatalf("PING: %v", err)
+		}
+		if got := calls.Load() - before; got < 2 {
+			t.Errorf("expected the hook to run for each new connection, got %d calls", got)
+		}
+	})
+
+	t.Run("hook error fails the command", func(t *testing.T) {
+		errSetup := errors.New("setup failed")
+		cfg := DefaultConfig()
+		cfg.OnConnect = func(ctx context.Context, cn *redis.Conn) error { return errSetup }
+		client := newClient(t, cfg)
+		if err := client.Ping(ctx).Err(); !errors.Is(err, errSetup) {
+			t.Errorf("expected the hook error, got %v", err)
+		}
+	})
+
+	t.Run("hook runs after connect commands", func(t *testing.T) {
+		var name string
+		cfg := DefaultConfig()
+		cfg.ConnectCommands = [][]any{{"client", "setname", "from-commands"}}
+		cfg.OnConnect = func(ctx context.Context, cn *redis.Conn) error {
+			var err error
+			name, err = cn.ClientGetName(ctx).Result()
+			return err
+		}
+		client := newClient(t, cfg)
+		if err := client.Ping(ctx).Err(); err != nil {
+			t.Fatalf("PING: %v", err)
+		}
+		if name != "from-commands" {
+			t.Errorf("expected the hook to see the connect commands applied, got %q", name)
+		}
+	})
+
+	t.Run("client name is set and survives Reset", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ClientName = "rediskit-named"
+		client := newClient(t, cfg)
+
+		cn := client.Conn()
+		defer cn.Close()
+		if name := cn.ClientGetName(ctx).Val(); name != "rediskit-named" {
+			t.Errorf("expected client name rediskit-named, got %q", name)
+		}
+		if err := client.Reset(ctx, cn); err != nil {
+			t.Fatalf("Reset: %v", err)
+		}
+		if name := cn.ClientGetName(ctx).Val(); name != "rediskit-named" {
+			t.Errorf("expected client name after Reset, got %q", name)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package rediskit
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// encryptTag marks a struct field whose JSON value is stored encrypted
+const encryptTag = "encrypt"
+
+// encryptedFieldCache maps struct types to the JSON names of their
+// encrypted fields
+var encryptedFieldCache sync.Map // reflect.Type -> []string
+
+// encryptedFields returns the JSON names of the top-level fields of t, or
+// the struct t points to, tagged rediskit:"encrypt"
+func encryptedFields(t reflect.Type) []string {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	if names, ok := encryptedFieldCache.Load(t); ok {
+		return names.([]string)
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("rediskit") != encryptTag {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	encryptedFieldCache.Store(t, names)
+	return names
+}
+
+// encryptFields replaces the encrypted fields of value in its JSON encoding
+// data with base64 AES-GCM ciphertexts. Values without such fields are
+// returned unchanged.
+func (c *Client) encryptFields(value any, data []byte) ([]byte, error) {
+	names := encryptedFields(reflect.TypeOf(value))
+	if len(names) == 0 {
+		return data, nil
+	}
+	aead, err := c.fieldCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return data, err
+	}
+	for _, name := range names {
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, fmt.Errorf("generate nonce: %w", err)
+		}
+		sealed := aead.Seal(nonce, nonce, raw, nil)
+		if fields[name], err = json.Marshal(base64.StdEncoding.EncodeToString(sealed)); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// decryptFields reverses encryptFields for the fields dest declares as
+// encrypted, returning ErrDecryptFailed for values that fail to decrypt
+func (c *Client) decryptFields(dest any, data []byte) ([]byte, error) {
+	names := encryptedFields(reflect.TypeOf(dest))
+	if len(names) == 0 {
+		return data, nil
+	}
+	aead, err := c.fieldCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return data, err
+	}
+	for _, name := range names {
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		var encoded string
+		if err := json.Unmarshal(raw, &encoded); err != nil {
+			return nil, fmt.Errorf("%w: field %q is not encrypted", ErrDecryptFailed, name)
+		}
+		sealed, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(sealed) < aead.NonceSize() {
+			return nil, fmt.Errorf("%w: field %q is malformed", ErrDecryptFailed, name)
+		}
+		nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+		if fields[name], err = aead.Open(nil, nonce, ciphertext, nil); err != nil {
+			return nil, fmt.Errorf("%w: field %q: %v", ErrDecryptFailed, name, err)
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// fieldCipher returns the AES-GCM cipher for EncryptionKey
+func (c *Client) fieldCipher() (cipher.AEAD, error) {
+	st := c.load()
+	if len(st.config.EncryptionKey) == 0 {
+		return nil, fmt.Errorf("%w: encrypted fields require an encryption key", ErrInvalidConfig)
+	}
+	block, err := aes.NewCipher(st.config.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	return cipher.NewGCM(block)
+}
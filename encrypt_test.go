@@ -0,0 +1,131 @@
+package rediskit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type encryptedRecord struct {
+	ID      int               `json:"id"`
+	Email   string            `json:"email"`
+	SSN     string            `json:"ssn" rediskit:"encrypt"`
+	Secrets map[string]string `rediskit:"encrypt"`
+	Skipped string            `json:"-" rediskit:"encrypt"`
+}
+
+// TestEncryptedFields tests finding fields tagged for encryption
+func TestEncryptedFields(t *testing.T) {
+	got := encryptedFields(reflect.TypeOf(&encryptedRecord{}))
+	want := []string{"ssn", "Secrets"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if names := encryptedFields(reflect.TypeOf(map[string]string{})); names != nil {
+		t.Errorf("expected no fields for a map, got %v", names)
+	}
+}
+
+// TestFieldEncryption tests encrypting tagged fields in JSON values
+func TestFieldEncryption(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	newClient := func(t *testing.T, key []byte) *Client {
+		cfg := DefaultConfig()
+		cfg.EncryptionKey = key
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+
+	t.Run("invalid key length", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.EncryptionKey = []byte("short")
+		if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	rec := encryptedRecord{ID: 1, Email: "a@example.com", SSN: "123-45-6789", Secrets: map[string]string{"pin": "0000"}}
+	plain, _ := json.Marshal(rec)
+
+	t.Run("only tagged fields are encrypted", func(t *testing.T) {
+		client := newClient(t, key)
+		data, err := client.encryptFields(rec, plain)
+		if err != nil {
+			t.Fatalf("encryptFields: %v", err)
+		}
+		if bytes.Contains(data, []byte("123-45-6789")) || bytes.Contains(data, []byte("0000")) {
+			t.Errorf("tagged fields stored in plaintext: %s", data)
+		}
+		if !bytes.Contains(data, []byte(`"email":"a@example.com"`)) {
+			t.Errorf("untagged field not stored in plaintext: %s", data)
+		}
+
+		data, err = client.decryptFields(&encryptedRecord{}, data)
+		if err != nil {
+			t.Fatalf("decryptFields: %v", err)
+		}
+		var got encryptedRecord
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal: %v", err)
+		}
+		if got.SSN != rec.SSN || got.Secrets["pin"] != "0000" || got.Email != rec.Email {
+			t.Errorf("expected %+v, got %+v", rec, got)
+		}
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		client := newClient(t, nil)
+		if _, err := client.encryptFields(rec, plain); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	t.Run("wrong key fails clearly", func(t *testing.T) {
+		data, err := newClient(t, key).encryptFields(rec, plain)
+		if err != nil {
+			t.Fatalf("encryptFields: %v", err)
+		}
+		other := newClient(t, bytes.Repeat([]byte{8}, 32))
+		_, err = other.decryptFields(&encryptedRecord{}, data)
+		if !errors.Is(err, ErrDecryptFailed) || !strings.Contains(err.Error(), "ssn") {
+			t.Errorf("expected ErrDecryptFailed naming the field, got %v", err)
+		}
+	})
+
+	t.Run("plaintext value fails clearly", func(t *testing.T) {
+		_, err := newClient(t, key).decryptFields(&encryptedRecord{}, plain)
+		if !errors.Is(err, ErrDecryptFailed) {
+			t.Errorf("expected ErrDecryptFailed, got %v", err)
+		}
+	})
+
+	t.Run("round trip through redis", func(t *testing.T) {
+		newTestClient(t) // skips without Redis
+		client := newClient(t, key)
+		ctx := context.Background()
+		redisKey := "rediskit:test:encrypted"
+		defer client.Del(ctx, redisKey)
+
+		if err := client.SetJSON(ctx, redisKey, rec, 0); err != nil {
+			t.Fatalf("SetJSON: %v", err)
+		}
+		if raw := client.Get(ctx, redisKey).Val(); strings.Contains(raw, rec.SSN) {
+			t.Errorf("SSN stored in plaintext: %s", raw)
+		}
+		var got encryptedRecord
+		if err := client.GetJSON(ctx, redisKey, &got); err != nil {
+			t.Fatalf("GetJSON: %v", err)
+		}
+		if got.SSN != rec.SSN || got.ID != rec.ID {
+			t.Errorf("expected %+v, got %+v", rec, got)
+		}
+	})
+}
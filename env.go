@@ -0,0 +1,118 @@
+package rediskit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewConfigFromEnv builds a Config from REDIS_* environment variables,
+// keeping DefaultConfig values for anything unset, and validates it. An
+// optional prefix reads <PREFIX>_REDIS_* instead, so NewConfigFromEnv("MYAPP")
+// reads MYAPP_REDIS_HOST.
+//
+// Recognized variables (without prefix):
+//
+//	REDIS_HOST, REDIS_PORT, REDIS_USERNAME, REDIS_PASSWORD, REDIS_DB,
+//	REDIS_POOL_SIZE, REDIS_MIN_IDLE_CONNS, REDIS_MAX_RETRIES,
+//	REDIS_DEFAULT_TIMEOUT, REDIS_SOCKET_TIMEOUT, REDIS_SOCKET_CONNECT_TIMEOUT,
+//	REDIS_MIN_RETRY_BACKOFF, REDIS_MAX_RETRY_BACKOFF,
+//	REDIS_CONN_MAX_IDLE_TIME, REDIS_CONN_MAX_LIFETIME,
+//	REDIS_HEALTH_CHECK_INTERVAL, REDIS_TLS, REDIS_TLS_SERVER_NAME
+//
+// Durations accept Go duration syntax ("500ms") or a whole number of seconds.
+func NewConfigFromEnv(prefix ...string) (*Config, error) {
+	name := "REDIS_"
+	if len(prefix) > 0 && prefix[0] != "" {
+		name = strings.TrimSuffix(prefix[0], "_") + "_REDIS_"
+	}
+
+	cfg := DefaultConfig()
+	env := envReader{prefix: name}
+
+	env.string("HOST", &cfg.Host)
+	env.string("PORT", &cfg.Port)
+	env.string("USERNAME", &cfg.Username)
+	env.string("PASSWORD", &cfg.Password)
+	env.int("DB", &cfg.DB)
+	env.int("POOL_SIZE", &cfg.PoolSize)
+	env.int("MIN_IDLE_CONNS", &cfg.MinIdleConns)
+	env.int("MAX_RETRIES", &cfg.MaxRetries)
+	env.duration("DEFAULT_TIMEOUT", &cfg.DefaultTimeout)
+	env.duration("SOCKET_TIMEOUT", &cfg.SocketTimeout)
+	env.duration("SOCKET_CONNECT_TIMEOUT", &cfg.SocketConnectTimeout)
+	env.duration("MIN_RETRY_BACKOFF", &cfg.MinRetryBackoff)
+	env.duration("MAX_RETRY_BACKOFF", &cfg.MaxRetryBackoff)
+	env.duration("CONN_MAX_IDLE_TIME", &cfg.ConnMaxIdleTime)
+	env.duration("CONN_MAX_LIFETIME", &cfg.ConnMaxLifetime)
+	env.duration("HEALTH_CHECK_INTERVAL", &cfg.HealthCheckInterval)
+	env.bool("TLS", &cfg.EnableTLS)
+	env.string("TLS_SERVER_NAME", &cfg.TLSServerName)
+
+	if env.err != nil {
+		return nil, env.err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// envReader reads prefixed environment variables, keeping the first error
+type envReader struct {
+	prefix string
+	err    error
+}
+
+func (r *envReader) lookup(name string) (string, string, bool) {
+	key := r.prefix + name
+	v, ok := os.LookupEnv(key)
+	return key, v, ok && r.err == nil
+}
+
+func (r *envReader) string(name string, dst *string) {
+	if _, v, ok := r.lookup(name); ok {
+		*dst = v
+	}
+}
+
+func (r *envReader) int(name string, dst *int) {
+	key, v, ok := r.lookup(name)
+	if !ok {
+		return
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		r.err = fmt.Errorf("%w: %s: invalid integer %q", ErrInvalidConfig, key, v)
+		return
+	}
+	*dst = n
+}
+
+func (r *envReader) duration(name string, dst *time.Duration) {
+	key, v, ok := r.lookup(name)
+	if !ok {
+		return
+	}
+	d, err := parseDuration(v)
+	if err != nil {
+		r.err = fmt.Errorf("%w: %s: invalid duration %q", ErrInvalidConfig, key, v)
+		return
+	}
+	*dst = d
+}
+
+func (r *envReader) bool(name string, dst *bool) {
+	key, v, ok := r.lookup(name)
+	if !ok {
+		return
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		r.err = fmt.Errorf("%w: %s: invalid boolean %q", ErrInvalidConfig, key, v)
+		return
+	}
+	*dst = b
+}
@@ -0,0 +1,108 @@
+package rediskit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNewConfigFromEnv tests loading configuration from the environment
+func TestNewConfigFromEnv(t *testing.T) {
+	t.Run("unset uses defaults", func(t *testing.T) {
+		cfg, err := NewConfigFromEnv("REDISKIT_TEST_UNSET")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		def := DefaultConfig()
+		if cfg.Host != def.Host || cfg.Port != def.Port || cfg.PoolSize != def.PoolSize {
+			t.Errorf("expected defaults, got %+v", cfg)
+		}
+	})
+
+	t.Run("reads variables", func(t *testing.T) {
+		t.Setenv("REDIS_HOST", "env-host")
+		t.Setenv("REDIS_PORT", "6390")
+		t.Setenv("REDIS_USERNAME", "app")
+		t.Setenv("REDIS_PASSWORD", "secret")
+		t.Setenv("REDIS_DB", "4")
+		t.Setenv("REDIS_POOL_SIZE", "25")
+		t.Setenv("REDIS_DEFAULT_TIMEOUT", "750ms")
+		t.Setenv("REDIS_SOCKET_TIMEOUT", "2")
+		t.Setenv("REDIS_TLS", "true")
+
+		cfg, err := NewConfigFromEnv()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		tests := []struct {
+			name     string
+			got      interface{}
+			expected interface{}
+		}{
+			{"Host", cfg.Host, "env-host"},
+			{"Port", cfg.Port, "6390"},
+			{"Username", cfg.Username, "app"},
+			{"Password", cfg.Password, "secret"},
+			{"DB", cfg.DB, 4},
+			{"PoolSize", cfg.PoolSize, 25},
+			{"DefaultTimeout", cfg.DefaultTimeout, 750 * time.Millisecond},
+			{"SocketTimeout", cfg.SocketTimeout, 2 * time.Second},
+			{"EnableTLS", cfg.EnableTLS, true},
+			{"MinIdleConns", cfg.MinIdleConns, 2},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if tt.got != tt.expected {
+					t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.expected)
+				}
+			})
+		}
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		t.Setenv("REDIS_HOST", "unprefixed")
+		t.Setenv("MYAPP_REDIS_HOST", "prefixed")
+
+		cfg, err := NewConfigFromEnv("MYAPP")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Host != "prefixed" {
+			t.Errorf("expected prefixed host, got %q", cfg.Host)
+		}
+
+		cfg, err = NewConfigFromEnv("MYAPP_")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Host != "prefixed" {
+			t.Errorf("expected trailing underscore to be tolerated, got %q", cfg.Host)
+		}
+	})
+
+	errTests := []struct {
+		name      string
+		key       string
+		value     string
+		errString string
+	}{
+		{"bad integer", "REDIS_POOL_SIZE", "lots", "REDIS_POOL_SIZE: invalid integer"},
+		{"bad duration", "REDIS_DEFAULT_TIMEOUT", "soon", "REDIS_DEFAULT_TIMEOUT: invalid duration"},
+		{"bad boolean", "REDIS_TLS", "maybe", "REDIS_TLS: invalid boolean"},
+		{"fails validation", "REDIS_POOL_SIZE", "0", "pool size must be greater than 0"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+			_, err := NewConfigFromEnv()
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("expected ErrInvalidConfig, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.errString) {
+				t.Errorf("expected error to contain %q, got %q", tt.errString, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AddToExpiringSet adds member to the expiring set at key for ttl, or
+// extends its expiry if it is already there. An expiring set is a sorted
+// set scored by each member's expiry in Unix nanoseconds; expired members
+// are pruned lazily, here and by ExpiringSetMembers. With ServerClock set,
+// expiries follow the server's clock.
+func (c *Client) AddToExpiringSet(ctx context.Context, key, member string, ttl time.Duration) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("%w: ttl must be greater than 0", ErrInvalidArgument)
+	}
+	now := c.now(ctx)
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	_, err := st.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", expiryScore(now))
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.Add(ttl).UnixNano()), Member: member})
+		return nil
+	})
+	return err
+}
+
+// ExpiringSetMembers removes the expired members of the expiring set at
+// key and returns the rest, soonest to expire first
+func (c *Client) ExpiringSetMembers(ctx context.Context, key string) ([]string, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	now := expiryScore(c.now(ctx))
+	ctx, cancel := c.readContext(ctx)
+	defer cancel()
+	var members *redis.StringSliceCmd
+	_, err := st.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", now)
+		members = pipe.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: "(" + now, Max: "+inf"})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return members.Val(), nil
+}
+
+// expiryScore formats t as an expiring set score bound
+func expiryScore(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,95 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestExpiringSet tests set membership with per-member expiry
+func TestExpiringSet(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.AddToExpiringSet(context.Background(), "k", "m", time.Second); err != ErrNilClient {
+			t.Errorf("AddToExpiringSet: expected ErrNilClient, got %v", err)
+		}
+		if _, err := client.ExpiringSetMembers(context.Background(), "k"); err != ErrNilClient {
+			t.Errorf("ExpiringSetMembers: expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if err := client.AddToExpiringSet(context.Background(), "k", "m", 0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	t.Run("members disappear after their ttl", func(t *testing.T) {
+		key := "rediskit:test:expiringset:online"
+		client.Del(ctx, key)
+		defer client.Del(ctx, key)
+
+		if err := client.AddToExpiringSet(ctx, key, "short", 50*time.Millisecond); err != nil {
+			t.Fatalf("AddToExpiringSet: %v", err)
+		}
+		if err := client.AddToExpiringSet(ctx, key, "long", time.Minute); err != nil {
+			t.Fatalf("AddToExpiringSet: %v", err)
+		}
+
+		members, err := client.ExpiringSetMembers(ctx, key)
+		if err != nil {
+			t.Fatalf("ExpiringSetMembers: %v", err)
+		}
+		if want := []string{"short", "long"}; !reflect.DeepEqual(members, want) {
+			t.Errorf("expected %v, got %v", want, members)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		members, err = client.ExpiringSetMembers(ctx, key)
+		if err != nil {
+			t.Fatalf("ExpiringSetMembers: %v", err)
+		}
+		if want := []string{"long"}; !reflect.DeepEqual(members, want) {
+			t.Errorf("expected %v after expiry, got %v", want, members)
+		}
+		if n := client.ZCard(ctx, key).Val(); n != 1 {
+			t.Errorf("expected expired members to be pruned, got %d members", n)
+		}
+	})
+
+	t.Run("re-adding extends the expiry", func(t *testing.T) {
+		key := "rediskit:test:expiringset:refresh"
+		client.Del(ctx, key)
+		defer client.Del(ctx, key)
+
+		client.AddToExpiringSet(ctx, key, "user", 50*time.Millisecond)
+		if err := client.AddToExpiringSet(ctx, key, "user", time.Minute); err != nil {
+			t.Fatalf("AddToExpiringSet: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+		members, err := client.ExpiringSetMembers(ctx, key)
+		if err != nil {
+			t.Fatalf("ExpiringSetMembers: %v", err)
+		}
+		if len(members) != 1 || members[0] != "user" {
+			t.Errorf("expected the member to still be present, got %v", members)
+		}
+	})
+
+	t.Run("missing key is empty", func(t *testing.T) {
+		members, err := client.ExpiringSetMembers(ctx, "rediskit:test:expiringset:missing")
+		if err != nil || len(members) != 0 {
+			t.Errorf("expected no members, got %v, %v", members, err)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package rediskit
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// FailoverConfig holds the configuration for a Sentinel-managed client. The
+// embedded Config supplies credentials, DB, pool, timeout, and retry
+// settings; its Host and Port are ignored in favor of the sentinels. For
+// TLS, set TLSServerName or TLSConfig since there is no Host to verify.
+type FailoverConfig struct {
+	Config
+	MasterName       string   // Name of the master monitored by the sentinels
+	SentinelAddrs    []string // host:port addresses of the sentinels
+	SentinelPassword string   // Password for the sentinels, if they require one
+}
+
+// Validate validates the failover configuration
+func (c *FailoverConfig) Validate() error {
+	if c.MasterName == "" {
+		return fmt.Errorf("%w: master name is required", ErrInvalidConfig)
+	}
+	if len(c.SentinelAddrs) == 0 {
+		return fmt.Errorf("%w: at least one sentinel address is required", ErrInvalidConfig)
+	}
+	return c.Config.validateShared()
+}
+
+// NewFailoverClient creates a client that discovers the current master
+// through Redis Sentinel and follows it across failovers
+func NewFailoverClient(cfg *FailoverConfig) (*Client, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: failover config is required", ErrInvalidConfig)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	rdb := redis.NewFailoverClient(cfg.failoverOptions())
+	if err := cfg.instrumentTracing(rdb); err != nil {
+		rdb.Close()
+		return nil, err
+	}
+
+	client := newClient(rdb, &cfg.Config, fmt.Sprintf("sentinel:%s/%d", cfg.MasterName, cfg.DB))
+	client.failover = true
+	replicas, err := client.newReplicas(&cfg.Config, nil)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	client.state.Store(&clientState{rdb: rdb, config: &cfg.Config, replicas: replicas})
+	return client, nil
+}
+
+// failoverOptions converts cfg to go-redis failover options. The settings
+// shared with a plain client come from Config.options, OptionsHook
+// included, so both kinds of client are configured the same way.
+func (c *FailoverConfig) failoverOptions() *redis.FailoverOptions {
+	opts := c.Config.options()
+	return &redis.FailoverOptions{
+		MasterName:       c.MasterName,
+		SentinelAddrs:    c.SentinelAddrs,
+		SentinelPassword: c.SentinelPassword,
+
+		ClientName:                   opts.ClientName,
+		Dialer:                       opts.Dialer,
+		OnConnect:                    opts.OnConnect,
+		Protocol:                     opts.Protocol,
+		Username:                     opts.Username,
+		Password:                     opts.Password,
+		CredentialsProvider:          opts.CredentialsProvider,
+		CredentialsProviderContext:   opts.CredentialsProviderContext,
+		StreamingCredentialsProvider: opts.StreamingCredentialsProvider,
+		DB:                           opts.DB,
+		MaxRetries:                   opts.MaxRetries,
+		MinRetryBackoff:              opts.MinRetryBackoff,
+		MaxRetryBackoff:              opts.MaxRetryBackoff,
+		DialTimeout:                  opts.DialTimeout,
+		ReadTimeout:                  opts.ReadTimeout,
+		WriteTimeout:                 opts.WriteTimeout,
+		ContextTimeoutEnabled:        opts.ContextTimeoutEnabled,
+		ReadBufferSize:               opts.ReadBufferSize,
+		WriteBufferSize:              opts.WriteBufferSize,
+		PoolFIFO:                     opts.PoolFIFO,
+		PoolSize:                     opts.PoolSize,
+		PoolTimeout:                  opts.PoolTimeout,
+		MinIdleConns:                 opts.MinIdleConns,
+		MaxIdleConns:                 opts.MaxIdleConns,
+		MaxActiveConns:               opts.MaxActiveConns,
+		ConnMaxIdleTime:              opts.ConnMaxIdleTime,
+		ConnMaxLifetime:              opts.ConnMaxLifetime,
+		TLSConfig:                    opts.TLSConfig,
+		DisableIdentity:              opts.DisableIdentity,
+		IdentitySuffix:               opts.IdentitySuffix,
+		FailingTimeoutSeconds:        opts.FailingTimeoutSeconds,
+		UnstableResp3:                opts.UnstableResp3,
+	}
+}
@@ -0,0 +1,125 @@
+package rediskit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func testFailoverConfig() *FailoverConfig {
+	return &FailoverConfig{
+		Config:        *DefaultConfig(),
+		MasterName:    "mymaster",
+		SentinelAddrs: []string{"127.0.0.1:1"},
+	}
+}
+
+// TestFailoverConfigValidate tests failover configuration validation
+func TestFailoverConfigValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(c *FailoverConfig)
+		errString string
+	}{
+		{name: "valid config", mutate: func(c *FailoverConfig) {}},
+		{name: "host and port are not required", mutate: func(c *FailoverConfig) { c.Host, c.Port = "", "" }},
+		{name: "empty master name", mutate: func(c *FailoverConfig) { c.MasterName = "" }, errString: "master name is required"},
+		{name: "no sentinels", mutate: func(c *FailoverConfig) { c.SentinelAddrs = nil }, errString: "at least one sentinel address is required"},
+		{name: "shared settings", mutate: func(c *FailoverConfig) { c.PoolSize = 0 }, errString: "pool size must be greater than 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testFailoverConfig()
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.errString == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("expected ErrInvalidConfig, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.errString) {
+				t.Errorf("expected error to contain %q, got %q", tt.errString, err.Error())
+			}
+		})
+	}
+}
+
+// TestNewFailoverClient tests Sentinel client creation
+func TestNewFailoverClient(t *testing.T) {
+	t.Run("nil config returns error", func(t *testing.T) {
+		if _, err := NewFailoverClient(nil); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	t.Run("invalid config returns error", func(t *testing.T) {
+		cfg := testFailoverConfig()
+		cfg.MasterName = ""
+		client, err := NewFailoverClient(cfg)
+		if err == nil {
+			t.Error("expected error for invalid config")
+		}
+		if client != nil {
+			t.Error("expected nil client for invalid config")
+		}
+	})
+
+	t.Run("behaves like a regular client", func(t *testing.T) {
+		cfg := testFailoverConfig()
+		cfg.PoolSize = 7
+		cfg.MaxRetries = -1
+		cfg.DefaultTimeout = 200 * time.Millisecond
+		client, err := NewFailoverClient(cfg)
+		if err != nil {
+			t.Fatalf("NewFailoverClient: %v", err)
+		}
+		defer client.Close()
+
+		if got := client.GetConfig(); got.PoolSize != 7 {
+			t.Errorf("GetConfig: expected pool size 7, got %d", got.PoolSize)
+		}
+		if client.Options().PoolSize != 7 {
+			t.Errorf("expected pool size 7 on the underlying client, got %d", client.Options().PoolSize)
+		}
+
+		// No sentinel is listening, so the health check must fail
+		if err := client.HealthCheck(); err == nil {
+			t.Error("expected health check to fail without sentinels")
+		}
+	})
+
+	t.Run("options match a regular client", func(t *testing.T) {
+		cfg := testFailoverConfig()
+		cfg.EnableTLS = true
+		cfg.TLSServerName = "redis.internal"
+		cfg.ConnectCommands = [][]any{{"client", "no-evict", "on"}}
+		cfg.OptionsHook = func(opts *redis.Options) {
+			opts.PoolTimeout = 3 * time.Second
+			opts.MaxActiveConns = 20
+			opts.ClientName = "checkout-service"
+		}
+
+		opts := cfg.failoverOptions()
+		if opts.MasterName != cfg.MasterName || len(opts.SentinelAddrs) != len(cfg.SentinelAddrs) {
+			t.Errorf("unexpected sentinel settings: %q, %v", opts.MasterName, opts.SentinelAddrs)
+		}
+		if opts.PoolTimeout != 3*time.Second || opts.MaxActiveConns != 20 || opts.ClientName != "checkout-service" {
+			t.Errorf("expected OptionsHook settings, got pool timeout %v, max active %d, name %q",
+				opts.PoolTimeout, opts.MaxActiveConns, opts.ClientName)
+		}
+		if opts.TLSConfig == nil || opts.TLSConfig.ServerName != "redis.internal" {
+			t.Errorf("expected TLS for redis.internal, got %+v", opts.TLSConfig)
+		}
+		if opts.OnConnect == nil {
+			t.Error("expected OnConnect to send ConnectCommands")
+		}
+	})
+}
@@ -0,0 +1,259 @@
+package rediskit
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// hUpdateIfScript applies the field/value pairs from ARGV[3:] to the hash at
+// KEYS[1] only if field ARGV[1] currently equals ARGV[2]
+var hUpdateIfScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], ARGV[1]) ~= ARGV[2] then
+	return 0
+end
+redis.call('HSET', KEYS[1], unpack(ARGV, 3))
+return 1
+`)
+
+// HUpdateIf atomically applies updates to the hash at key only if condField
+// currently equals condValue, returning whether the updates were applied. A
+// missing hash or field never matches.
+func (c *Client) HUpdateIf(ctx context.Context, key, condField, condValue string, updates map[string]any) (bool, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return false, ErrNilClient
+	}
+	if len(updates) == 0 {
+		return false, fmt.Errorf("%w: no fields to update", ErrInvalidArgument)
+	}
+
+	args := make([]any, 0, 2+2*len(updates))
+	args = append(args, condField, condValue)
+	for field, value := range updates {
+		args = append(args, field, value)
+	}
+
+	applied, err := hUpdateIfScript.Run(ctx, st.rdb, []string{key}, args...).Int()
+	if err != nil {
+		return false, err
+	}
+	return applied == 1, nil
+}
+
+// hashField maps a struct field to a hash field
+type hashField struct {
+	index     int
+	name      string
+	omitEmpty bool
+}
+
+// hashFields returns the hash fields of struct type t. Exported fields are
+// named by their redis tag, or the field name when untagged; a tag of "-"
+// skips the field, and the omitempty option skips zero values on write.
+func hashFields(t reflect.Type) []hashField {
+	fields := make([]hashField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("redis")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, hashField{index: i, name: name, omitEmpty: opts == "omitempty"})
+	}
+	return fields
+}
+
+// HSetStruct stores the struct v, or a pointer to one, as the hash at key,
+// one hash field per struct field as mapped by redis tags, with ttl as the
+// key's expiry (0 for none). Fields left out by omitempty are removed from
+// the hash, so it always mirrors v. Strings, numbers, bools, []byte,
+// time.Time, and encoding.TextMarshaler values are supported.
+// WriteTimeout applies when ctx has no deadline.
+func (c *Client) HSetStruct(ctx context.Context, key string, v any, ttl time.Duration) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if ttl < 0 {
+		return fmt.Errorf("%w: ttl must not be negative", ErrInvalidArgument)
+	}
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: v must be a struct or a pointer to one, got %T", ErrInvalidArgument, v)
+	}
+
+	var values []any
+	var omitted []string
+	for _, f := range hashFields(rv.Type()) {
+		fv := rv.Field(f.index)
+		if f.omitEmpty && fv.IsZero() {
+			omitted = append(omitted, f.name)
+			continue
+		}
+		s, err := formatHashValue(fv)
+		if err != nil {
+			return fmt.Errorf("encode %q field %q: %w", key, f.name, err)
+		}
+		values = append(values, f.name, s)
+	}
+
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	_, err := st.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(values) == 0 {
+			pipe.Del(ctx, key)
+			return nil
+		}
+		if len(omitted) > 0 {
+			pipe.HDel(ctx, key, omitted...)
+		}
+		pipe.HSet(ctx, key, values...)
+		if ttl > 0 {
+			pipe.Expire(ctx, key, ttl)
+		} else {
+			pipe.Persist(ctx, key)
+		}
+		return nil
+	})
+	return err
+}
+
+// HGetStruct decodes the hash at key into the struct dest points to,
+// mapping fields as HSetStruct does. Hash fields without a matching struct
+// field are ignored, and struct fields missing from the hash are left
+// unchanged. It returns ErrCacheMiss when the hash does not exist.
+// ReadTimeout applies when ctx has no deadline.
+func (c *Client) HGetStruct(ctx context.Context, key string, dest any) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: dest must be a non-nil pointer to a struct, got %T", ErrInvalidArgument, dest)
+	}
+
+	ctx, cancel := c.readContext(ctx)
+	defer cancel()
+	hash, err := st.rdb.HGetAll(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if len(hash) == 0 {
+		return ErrCacheMiss
+	}
+
+	target := rv.Elem()
+	for _, f := range hashFields(target.Type()) {
+		s, ok := hash[f.name]
+		if !ok {
+			continue
+		}
+		if err := parseHashValue(target.Field(f.index), s); err != nil {
+			return fmt.Errorf("decode %q field %q: %w", key, f.name, err)
+		}
+	}
+	return nil
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// formatHashValue renders a struct field as a hash value
+func formatHashValue(v reflect.Value) (string, error) {
+	switch {
+	case v.Type() == timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	case v.Type().Implements(textMarshalerType):
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes()), nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// parseHashValue sets the struct field v from a hash value
+func parseHashValue(v reflect.Value, s string) error {
+	switch {
+	case v.Type() == timeType:
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case reflect.PointerTo(v.Type()).Implements(textUnmarshalerType):
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(s))
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported type %s", v.Type())
+}
@@ -0,0 +1,230 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestHUpdateIf tests conditional multi-field hash updates
+func TestHUpdateIf(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.HUpdateIf(context.Background(), "k", "f", "v", map[string]any{"a": 1}); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("empty updates", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.HUpdateIf(context.Background(), "k", "f", "v", nil); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:hupdateif"
+	client.Del(ctx, key)
+	defer client.Del(ctx, key)
+	client.HSet(ctx, key, "status", "pending", "attempts", 0)
+
+	t.Run("condition matches", func(t *testing.T) {
+		ok, err := client.HUpdateIf(ctx, key, "status", "pending", map[string]any{"status": "running", "attempts": 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !ok {
+			t.Fatal("expected update to apply")
+		}
+		got := client.HGetAll(ctx, key).Val()
+		if got["status"] != "running" || got["attempts"] != "1" {
+			t.Errorf("unexpected hash %v", got)
+		}
+	})
+
+	t.Run("condition does not match", func(t *testing.T) {
+		ok, err := client.HUpdateIf(ctx, key, "status", "pending", map[string]any{"status": "done"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok {
+			t.Error("expected update to be skipped")
+		}
+		if v := client.HGet(ctx, key, "status").Val(); v != "running" {
+			t.Errorf("status changed to %q", v)
+		}
+	})
+
+	t.Run("missing hash", func(t *testing.T) {
+		missing := key + ":missing"
+		ok, err := client.HUpdateIf(ctx, missing, "status", "pending", map[string]any{"status": "done"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok {
+			t.Error("expected update to be skipped for a missing hash")
+		}
+		if n := client.Exists(ctx, missing).Val(); n != 0 {
+			t.Error("missing hash was created")
+		}
+	})
+}
+
+// TestHashStruct tests mapping structs to and from hashes
+func TestHashStruct(t *testing.T) {
+	type settings struct {
+		Name     string        `redis:"name"`
+		Limit    int           `redis:"limit"`
+		Ratio    float64       `redis:"ratio,omitempty"`
+		Enabled  bool          `redis:"enabled"`
+		Timeout  time.Duration `redis:"timeout"`
+		Updated  time.Time     `redis:"updated"`
+		Region   string        // untagged fields use the field name
+		Internal string        `redis:"-"`
+		secret   string
+	}
+
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.HSetStruct(context.Background(), "k", settings{}, 0); err != ErrNilClient {
+			t.Errorf("HSetStruct: expected ErrNilClient, got %v", err)
+		}
+		var s settings
+		if err := client.HGetStruct(context.Background(), "k", &s); err != ErrNilClient {
+			t.Errorf("HGetStruct: expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		ctx := context.Background()
+
+		if err := client.HSetStruct(ctx, "k", 42, 0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("HSetStruct non-struct: expected ErrInvalidArgument, got %v", err)
+		}
+		if err := client.HSetStruct(ctx, "k", settings{}, -time.Second); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("HSetStruct negative ttl: expected ErrInvalidArgument, got %v", err)
+		}
+		for _, dest := range []any{nil, settings{}, (*settings)(nil), new(int)} {
+			if err := client.HGetStruct(ctx, "k", dest); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("HGetStruct(%T): expected ErrInvalidArgument, got %v", dest, err)
+			}
+		}
+		type bad struct {
+			Tags []string `redis:"tags"`
+		}
+		if err := client.HSetStruct(ctx, "k", bad{Tags: []string{"a"}}, 0); err == nil {
+			t.Error("expected an error for an unsupported field type")
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	const prefix = "rediskit:test:hashstruct:"
+
+	t.Run("round trip", func(t *testing.T) {
+		key := prefix + "roundtrip"
+		defer client.Del(ctx, key)
+		want := settings{
+			Name:     "checkout",
+			Limit:    100,
+			Ratio:    0.25,
+			Enabled:  true,
+			Timeout:  3 * time.Second,
+			Updated:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Region:   "eu",
+			Internal: "not stored",
+			secret:   "not stored",
+		}
+		if err := client.HSetStruct(ctx, key, &want, time.Minute); err != nil {
+			t.Fatalf("HSetStruct: %v", err)
+		}
+
+		hash := client.HGetAll(ctx, key).Val()
+		expected := map[string]string{
+			"name": "checkout", "limit": "100", "ratio": "0.25", "enabled": "true",
+			"timeout": "3000000000", "updated": "2024-05-01T12:00:00Z", "Region": "eu",
+		}
+		if !reflect.DeepEqual(hash, expected) {
+			t.Errorf("expected hash %v, got %v", expected, hash)
+		}
+		if ttl := client.TTL(ctx, key).Val(); ttl <= 0 {
+			t.Errorf("expected a TTL, got %v", ttl)
+		}
+
+		var got settings
+		if err := client.HGetStruct(ctx, key, &got); err != nil {
+			t.Fatalf("HGetStruct: %v", err)
+		}
+		want.Internal, want.secret = "", ""
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	})
+
+	t.Run("omitted empty values are removed", func(t *testing.T) {
+		key := prefix + "omitempty"
+		defer client.Del(ctx, key)
+		if err := client.HSetStruct(ctx, key, settings{Name: "a", Ratio: 0.5}, 0); err != nil {
+			t.Fatalf("HSetStruct: %v", err)
+		}
+		if err := client.HSetStruct(ctx, key, settings{Name: "a"}, 0); err != nil {
+			t.Fatalf("HSetStruct: %v", err)
+		}
+		if client.HExists(ctx, key, "ratio").Val() {
+			t.Error("expected the zero omitempty field to be removed")
+		}
+		if v := client.HGet(ctx, key, "limit").Val(); v != "0" {
+			t.Errorf("expected zero values without omitempty to be stored, got %q", v)
+		}
+		if ttl := client.TTL(ctx, key).Val(); ttl != -1 {
+			t.Errorf("expected no TTL, got %v", ttl)
+		}
+	})
+
+	t.Run("unknown hash fields are ignored", func(t *testing.T) {
+		key := prefix + "extra"
+		defer client.Del(ctx, key)
+		client.HSet(ctx, key, "name", "b", "legacy_field", "x")
+
+		got := settings{Limit: 7}
+		if err := client.HGetStruct(ctx, key, &got); err != nil {
+			t.Fatalf("HGetStruct: %v", err)
+		}
+		if got.Name != "b" || got.Limit != 7 {
+			t.Errorf("expected name b and limit left at 7, got %+v", got)
+		}
+	})
+
+	t.Run("malformed value", func(t *testing.T) {
+		key := prefix + "malformed"
+		defer client.Del(ctx, key)
+		client.HSet(ctx, key, "limit", "many")
+
+		var got settings
+		err := client.HGetStruct(ctx, key, &got)
+		if err == nil || errors.Is(err, ErrCacheMiss) {
+			t.Errorf("expected a decode error, got %v", err)
+		}
+	})
+
+	t.Run("miss", func(t *testing.T) {
+		key := prefix + "missing"
+		client.Del(ctx, key)
+		var got settings
+		if err := client.HGetStruct(ctx, key, &got); !errors.Is(err, ErrCacheMiss) {
+			t.Errorf("expected ErrCacheMiss, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// HealthReport describes the server as seen by a detailed health check.
+// Fields the server does not report are left at their zero value.
+type HealthReport struct {
+	Latency          time.Duration // PING round-trip time
+	Role             string        // "master" or "slave", from INFO replication
+	ConnectedClients int           // from INFO clients
+	Warning          string        // a likely misconfiguration, such as clusterModeWarning
+}
+
+// clusterModeWarning is reported when a plain client talks to a cluster node
+const clusterModeWarning = "server appears to be in cluster mode; use NewClusterClient"
+
+// clusterProbeKey is looked up by HealthCheckDetailed to provoke a cluster
+// redirect from a node that does not serve it
+const clusterProbeKey = "rediskit:health:probe"
+
+// HealthCheckDetailed pings the server and reports its latency, role, and
+// client count. Only a failed ping fails the check; INFO sections that are
+// missing or unreadable just leave their fields empty. If the server runs
+// in cluster mode or answers a key lookup with a cluster redirect, Warning
+// says to use NewClusterClient. A nil ctx or one without a deadline gets
+// DefaultTimeout.
+func (c *Client) HealthCheckDetailed(ctx context.Context) (*HealthReport, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := c.ctxWithTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	if err := st.rdb.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+	report := &HealthReport{Latency: time.Since(start)}
+
+	pipe := st.rdb.Pipeline()
+	replication := pipe.Info(ctx, "replication")
+	clients := pipe.Info(ctx, "clients")
+	cluster := pipe.Info(ctx, "cluster")
+	probe := pipe.Exists(ctx, clusterProbeKey)
+	pipe.Exec(ctx) // per-section errors are checked below
+
+	if info, err := replication.Result(); err == nil {
+		report.Role = parseInfo(info)["role"]
+	}
+	if info, err := clients.Result(); err == nil {
+		report.ConnectedClients, _ = strconv.Atoi(parseInfo(info)["connected_clients"])
+	}
+	info, err := cluster.Result()
+	if IsClusterRedirect(probe.Err()) || (err == nil && parseInfo(info)["cluster_enabled"] == "1") {
+		report.Warning = clusterModeWarning
+	}
+	return report, nil
+}
+
+// HealthEvent reports a change in the client's health
+type HealthEvent struct {
+	Healthy bool
+	Err     error // the failed check's error when unhealthy
+	At      time.Time
+}
+
+// StartHealthMonitor checks the connection every HealthCheckInterval and
+// sends an event on the returned channel whenever health changes, starting
+// with the result of the first check. With TrackHitRatio set it also
+// samples the keyspace hit ratio on each healthy check. The monitor stops
+// and closes the channel when ctx is cancelled or the client shuts down.
+func (c *Client) StartHealthMonitor(ctx context.Context) <-chan HealthEvent {
+	st := c.load()
+	events := make(chan HealthEvent, 1)
+	if st.rdb == nil {
+		close(events)
+		return events
+	}
+
+	interval := st.config.HealthCheckInterval
+	if interval <= 0 {
+		interval = DefaultConfig().HealthCheckInterval
+	}
+	check := func(ctx context.Context) error {
+		err := c.HealthCheckContext(ctx)
+		if threshold := st.config.CircuitThreshold; threshold > 0 && ctx.Err() == nil {
+			c.circuit.record(time.Now(), err == nil, threshold)
+		}
+		if err == nil && st.config.TrackHitRatio {
+			c.SampleHitRatio(ctx)
+		}
+		return err
+	}
+	ctx, cancel := c.backgroundContext(ctx)
+	go func() {
+		defer cancel()
+		monitorHealth(ctx, interval, check, events, st.config.Logger)
+	}()
+	return events
+}
+
+// monitorHealth runs check every interval until ctx is done, sending an
+// event on each change of health, then closes events. Changes are also
+// logged to logger unless it is nil.
+func monitorHealth(ctx context.Context, interval time.Duration, check func(context.Context) error, events chan<- HealthEvent, logger Logger) {
+	defer close(events)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	first, healthy := true, false
+	for {
+		err := check(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if first || (err == nil) != healthy {
+			first, healthy = false, err == nil
+			if logger != nil {
+				if healthy {
+					logger.Infof("health check passed")
+				} else {
+					logger.Warnf("health check failed: %v", err)
+				}
+			}
+			select {
+			case events <- HealthEvent{Healthy: healthy, Err: err, At: time.Now()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// WaitForReady pings the server every interval until a ping succeeds, for
+// startup when Redis may not be up yet. When ctx is done first, it returns
+// an error wrapping both the context error and the last ping error.
+func (c *Client) WaitForReady(ctx context.Context, interval time.Duration) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if interval <= 0 {
+		return fmt.Errorf("%w: interval must be greater than 0", ErrInvalidArgument)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := st.rdb.Ping(ctx).Err()
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("redis not ready: %w: last ping error: %w", ctx.Err(), err)
+		}
+		if logger := st.config.Logger; logger != nil {
+			logger.Debugf("waiting for redis: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("redis not ready: %w: last ping error: %w", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestHealthCheckDetailed tests detailed health reports
+func TestHealthCheckDetailed(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.HealthCheckDetailed(context.Background()); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("unreachable server fails", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Port = "1"
+		cfg.MaxRetries = -1
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.HealthCheckDetailed(context.Background()); err == nil {
+			t.Error("expected error for unreachable server")
+		}
+	})
+
+	client := newTestClient(t)
+
+	//lint:ignore SA1012 a nil context is explicitly supported
+	report, err := client.HealthCheckDetailed(nil)
+	if err != nil {
+		t.Fatalf("HealthCheckDetailed: %v", err)
+	}
+	if report.Latency <= 0 {
+		t.Errorf("expected positive latency, got %v", report.Latency)
+	}
+	if report.ConnectedClients <= 0 {
+		t.Errorf("expected connected clients to be reported, got %d", report.ConnectedClients)
+	}
+	// The role is optional: servers that do not report replication leave it empty
+	if report.Role != "" && report.Role != "master" && report.Role != "slave" {
+		t.Errorf("unexpected role %q", report.Role)
+	}
+	if report.Warning != "" {
+		t.Errorf("expected no warning, got %q", report.Warning)
+	}
+
+	t.Run("cluster redirect is reported", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		client.AddHook(movedHook{})
+
+		report, err := client.HealthCheckDetailed(context.Background())
+		if err != nil {
+			t.Fatalf("HealthCheckDetailed: %v", err)
+		}
+		if report.Warning != clusterModeWarning {
+			t.Errorf("expected the cluster mode warning, got %q", report.Warning)
+		}
+	})
+}
+
+// movedHook answers keyed lookups in pipelines with a MOVED redirect, as a
+// cluster node does for a slot it does not serve
+type movedHook struct{}
+
+func (movedHook) DialHook(next redis.DialHook) redis.DialHook          { return next }
+func (movedHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook { return next }
+
+func (movedHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			if cmd.Name() == "exists" {
+				cmd.SetErr(serverError("MOVED 3999 127.0.0.1:6381"))
+			}
+		}
+		return err
+	}
+}
+
+// TestMonitorHealth tests that health events are sent only on changes
+func TestMonitorHealth(t *testing.T) {
+	errDown := errors.New("down")
+	results := []error{nil, nil, errDown, errDown, errDown, nil, nil}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls int
+	check := func(context.Context) error {
+		if calls == len(results)-1 {
+			cancel()
+		}
+		err := results[calls]
+		calls++
+		return err
+	}
+
+	events := make(chan HealthEvent, 1)
+	logger := &recordingLogger{}
+	go monitorHealth(ctx, time.Millisecond, check, events, logger)
+
+	var got []HealthEvent
+	for ev := range events {
+		got = append(got, ev)
+	}
+
+	want := []bool{true, false, true}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events, got %d: %+v", len(want), len(got), got)
+	}
+	for i, ev := range got {
+		if ev.Healthy != want[i] {
+			t.Errorf("event %d: expected healthy=%v, got %v", i, want[i], ev.Healthy)
+		}
+		if ev.At.IsZero() {
+			t.Errorf("event %d: missing timestamp", i)
+		}
+	}
+	if !errors.Is(got[1].Err, errDown) {
+		t.Errorf("expected the unhealthy event to carry the check error, got %v", got[1].Err)
+	}
+	if lines := logger.lines(); !reflect.DeepEqual(lines, []string{"INFO health check passed", "WARN health check failed: down", "INFO health check passed"}) {
+		t.Errorf("unexpected log lines %q", lines)
+	}
+}
+
+// TestStartHealthMonitor tests the monitor against a real connection
+func TestStartHealthMonitor(t *testing.T) {
+	t.Run("nil client closes immediately", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, ok := <-client.StartHealthMonitor(context.Background()); ok {
+			t.Error("expected a closed channel")
+		}
+	})
+
+	newTestClient(t) // skips without Redis
+	cfg := DefaultConfig()
+	cfg.HealthCheckInterval = 10 * time.Millisecond
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := client.StartHealthMonitor(ctx)
+	select {
+	case ev := <-events:
+		if !ev.Healthy {
+			t.Errorf("expected healthy first event, got %+v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no initial health event")
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("expected no further events while healthy")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("monitor did not stop after cancel")
+	}
+}
+
+// TestWaitForReady tests waiting for the server at startup
+func TestWaitForReady(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.WaitForReady(context.Background(), time.Millisecond); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if err := client.WaitForReady(context.Background(), 0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("unreachable server returns the last ping error", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Port = "1" // nothing listens here
+		cfg.MinIdleConns = 0
+		cfg.MaxRetries = -1
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err = client.WaitForReady(ctx, 10*time.Millisecond)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected the context error, got %v", err)
+		}
+		var opErr *net.OpError
+		if !errors.As(err, &opErr) {
+			t.Errorf("expected the last ping error to be wrapped, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.WaitForReady(ctx, 10*time.Millisecond); err != nil {
+		t.Errorf("expected a reachable server to be ready, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// hitRatioTracker turns the server's cumulative keyspace hit and miss
+// counters into a per-interval hit ratio
+type hitRatioTracker struct {
+	mu      sync.Mutex
+	sampled bool
+	hits    int64
+	misses  int64
+	ratio   float64
+}
+
+// observe records the counters from one sample and returns the hit ratio
+// since the previous one. A counter going backwards means the server
+// restarted, so the new counters are taken as the delta. Intervals without
+// any lookups keep the previous ratio.
+func (t *hitRatioTracker) observe(hits, misses int64) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	dh, dm := hits, misses
+	if t.sampled && hits >= t.hits && misses >= t.misses {
+		dh, dm = hits-t.hits, misses-t.misses
+	}
+	t.hits, t.misses, t.sampled = hits, misses, true
+	if dh+dm > 0 {
+		t.ratio = float64(dh) / float64(dh+dm)
+	}
+	return t.ratio
+}
+
+// HitRatio returns the keyspace hit ratio measured by the most recent
+// SampleHitRatio call, between 0 and 1. It is 0 until a sample with any
+// lookups has been taken.
+func (c *Client) HitRatio() float64 {
+	c.hitRatio.mu.Lock()
+	defer c.hitRatio.mu.Unlock()
+	return c.hitRatio.ratio
+}
+
+// SampleHitRatio reads keyspace_hits and keyspace_misses from INFO stats
+// and returns the hit ratio since the previous sample. The first sample
+// covers the time since the server started. Server restarts, which reset
+// the counters, are detected and handled.
+func (c *Client) SampleHitRatio(ctx context.Context) (float64, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return 0, ErrNilClient
+	}
+	info, err := st.rdb.Info(ctx, "stats").Result()
+	if err != nil {
+		return 0, err
+	}
+	stats := parseInfo(info)
+	hits, err := strconv.ParseInt(stats["keyspace_hits"], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse keyspace_hits: %w", err)
+	}
+	misses, err := strconv.ParseInt(stats["keyspace_misses"], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse keyspace_misses: %w", err)
+	}
+	return c.hitRatio.observe(hits, misses), nil
+}
@@ -0,0 +1,70 @@
+package rediskit
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+// TestHitRatioTracker tests deriving interval hit ratios from counters
+func TestHitRatioTracker(t *testing.T) {
+	steps := []struct {
+		name         string
+		hits, misses int64
+		want         float64
+	}{
+		{"first sample covers server lifetime", 30, 10, 0.75},
+		{"delta since previous sample", 40, 20, 0.5},
+		{"idle interval keeps previous ratio", 40, 20, 0.5},
+		{"restart resets counters", 9, 1, 0.9},
+		{"after restart", 10, 4, 1.0 / 4},
+	}
+
+	var tracker hitRatioTracker
+	for _, s := range steps {
+		if got := tracker.observe(s.hits, s.misses); math.Abs(got-s.want) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", s.name, s.want, got)
+		}
+	}
+}
+
+// TestSampleHitRatio tests sampling the hit ratio from INFO stats
+func TestSampleHitRatio(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.SampleHitRatio(context.Background()); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+		if r := client.HitRatio(); r != 0 {
+			t.Errorf("expected 0 before sampling, got %v", r)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	stats := parseInfo(client.Info(ctx, "stats").Val())
+	if _, ok := stats["keyspace_hits"]; !ok {
+		t.Skip("server does not report keyspace_hits")
+	}
+
+	key := "rediskit:test:hitratio"
+	defer client.Del(ctx, key)
+	client.Set(ctx, key, "v", 0)
+	if _, err := client.SampleHitRatio(ctx); err != nil {
+		t.Fatalf("SampleHitRatio: %v", err)
+	}
+
+	client.Get(ctx, key)
+	client.Get(ctx, key)
+	client.Get(ctx, key+":missing")
+	ratio, err := client.SampleHitRatio(ctx)
+	if err != nil {
+		t.Fatalf("SampleHitRatio: %v", err)
+	}
+	if ratio <= 0 || ratio > 1 {
+		t.Errorf("expected a ratio in (0, 1], got %v", ratio)
+	}
+	if client.HitRatio() != ratio {
+		t.Errorf("HitRatio %v does not match the last sample %v", client.HitRatio(), ratio)
+	}
+}
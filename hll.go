@@ -0,0 +1,54 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+)
+
+// PFAddItems adds items to the HyperLogLog at key. Like the other
+// single-key helpers it uses key as given, so pass BuildKey output to
+// namespace it. Adding no items is a no-op.
+func (c *Client) PFAddItems(ctx context.Context, key string, items ...string) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	args := make([]any, len(items))
+	for i, item := range items {
+		args[i] = item
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	return st.rdb.PFAdd(ctx, key, args...).Err()
+}
+
+// PFCount returns the approximate number of distinct items added to the
+// HyperLogLogs at keys. With several keys it counts their union. Missing
+// keys count as empty; the estimate has a standard error of 0.81%.
+func (c *Client) PFCount(ctx context.Context, keys ...string) (int64, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return 0, ErrNilClient
+	}
+	if len(keys) == 0 {
+		return 0, fmt.Errorf("%w: at least one key is required", ErrInvalidArgument)
+	}
+	ctx, cancel := c.readContext(ctx)
+	defer cancel()
+	return st.rdb.PFCount(ctx, keys...).Result()
+}
+
+// PFMerge stores the union of the HyperLogLogs at sources into dest, merging
+// with any HyperLogLog already at dest
+func (c *Client) PFMerge(ctx context.Context, dest string, sources ...string) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	return st.rdb.PFMerge(ctx, dest, sources...).Err()
+}
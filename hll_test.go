@@ -0,0 +1,104 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// TestHyperLogLog tests approximate counting of overlapping item sets
+func TestHyperLogLog(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		ctx := context.Background()
+		if err := client.PFAddItems(ctx, "k", "a"); err != ErrNilClient {
+			t.Errorf("PFAddItems: expected ErrNilClient, got %v", err)
+		}
+		if _, err := client.PFCount(ctx, "k"); err != ErrNilClient {
+			t.Errorf("PFCount: expected ErrNilClient, got %v", err)
+		}
+		if err := client.PFMerge(ctx, "dest", "k"); err != ErrNilClient {
+			t.Errorf("PFMerge: expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("with valid client", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+		keys := []string{"rediskit:test:hll:a", "rediskit:test:hll:b", "rediskit:test:hll:merged"}
+		defer client.Del(ctx, keys...)
+		client.Del(ctx, keys...)
+
+		if _, err := client.PFCount(ctx); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument for no keys, got %v", err)
+		}
+		if err := client.PFAddItems(ctx, keys[0]); err != nil {
+			t.Errorf("expected no error for no items, got %v", err)
+		}
+
+		// a holds items 0-5999 and b holds 4000-9999, so 10000 are distinct
+		add := func(key string, from, to int) {
+			items := make([]string, 0, to-from)
+			for i := from; i < to; i++ {
+				items = append(items, fmt.Sprintf("user:%d", i))
+			}
+			if err := client.PFAddItems(ctx, key, items...); err != nil {
+				t.Fatalf("PFAddItems: %v", err)
+			}
+		}
+		add(keys[0], 0, 6000)
+		add(keys[1], 4000, 10000)
+
+		if err := client.PFMerge(ctx, keys[2], keys[0], keys[1]); err != nil {
+			t.Fatalf("PFMerge: %v", err)
+		}
+
+		// Allow three standard errors of 0.81%
+		tests := []struct {
+			name string
+			keys []string
+			want float64
+		}{
+			{"single key", keys[:1], 6000},
+			{"merged key", keys[2:], 10000},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := client.PFCount(ctx, tt.keys...)
+				if err != nil {
+					t.Fatalf("PFCount: %v", err)
+				}
+				if math.Abs(float64(got)-tt.want) > tt.want*3*0.0081 {
+					t.Errorf("PFCount(%v) = %d, want %v within 2.43%%", tt.keys, got, tt.want)
+				}
+			})
+		}
+
+	})
+
+	t.Run("BuildKey output is used as given", func(t *testing.T) {
+		base := newTestClient(t)
+		cfg := *base.GetConfig()
+		cfg.KeyPrefix = "rediskit:test:hll:"
+		client := clientWith(base.Client, &cfg)
+		ctx := context.Background()
+		key, err := client.BuildKey("dau")
+		if err != nil {
+			t.Fatalf("BuildKey: %v", err)
+		}
+		defer client.Del(ctx, key)
+		client.Del(ctx, key)
+
+		if err := client.PFAddItems(ctx, key, "user:1", "user:2", "user:1"); err != nil {
+			t.Fatalf("PFAddItems: %v", err)
+		}
+		if n, _ := client.Exists(ctx, key).Result(); n != 1 {
+			t.Errorf("expected the HyperLogLog at %q", key)
+		}
+		if got, err := client.PFCount(ctx, key); err != nil || got != 2 {
+			t.Errorf("PFCount(%q) = %d, %v; want 2", key, got, err)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package rediskit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AddHook adds h to the go-redis client and any replica clients, like
+// Config.Hooks. Unlike calling AddHook on the embedded redis.Client, the
+// hook is kept when Reconfigure replaces the underlying clients.
+func (c *Client) AddHook(h redis.Hook) {
+	c.reconfigure.Lock()
+	defer c.reconfigure.Unlock()
+	c.hooks = append(c.hooks, h)
+	st := c.load()
+	if st.rdb == nil {
+		return
+	}
+	st.rdb.AddHook(h)
+	for _, replica := range st.replicas {
+		replica.AddHook(h)
+	}
+}
+
+// SlowCommandHook returns a hook that calls fn with the command name and
+// duration of every command taking longer than threshold. Pipelines and
+// transactions are reported as a whole, named "pipeline".
+func SlowCommandHook(threshold time.Duration, fn func(cmd string, d time.Duration)) redis.Hook {
+	return slowCommandHook{threshold: threshold, fn: fn}
+}
+
+// slowCommandHook implements SlowCommandHook
+type slowCommandHook struct {
+	threshold time.Duration
+	fn        func(cmd string, d time.Duration)
+}
+
+func (h slowCommandHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h slowCommandHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		if d := time.Since(start); d > h.threshold {
+			h.fn(cmd.Name(), d)
+		}
+		return err
+	}
+}
+
+func (h slowCommandHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		if d := time.Since(start); d > h.threshold {
+			h.fn("pipeline", d)
+		}
+		return err
+	}
+}
@@ -0,0 +1,139 @@
+package rediskit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestSlowCommandHook tests reporting commands slower than a threshold
+func TestSlowCommandHook(t *testing.T) {
+	type report struct {
+		cmd string
+		d   time.Duration
+	}
+	var reports []report
+	hook := SlowCommandHook(20*time.Millisecond, func(cmd string, d time.Duration) {
+		reports = append(reports, report{cmd, d})
+	})
+	ctx := context.Background()
+
+	// taking returns a fake command step that takes d
+	taking := func(d time.Duration) redis.ProcessHook {
+		return func(context.Context, redis.Cmder) error {
+			time.Sleep(d)
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name     string
+		cmd      redis.Cmder
+		duration time.Duration
+		wantSlow bool
+	}{
+		{"fast command", redis.NewStatusCmd(ctx, "ping"), 0, false},
+		{"slow command", redis.NewStringCmd(ctx, "get", "k"), 40 * time.Millisecond, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reports = nil
+			if err := hook.ProcessHook(taking(tt.duration))(ctx, tt.cmd); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantSlow {
+				if len(reports) != 0 {
+					t.Errorf("expected no report, got %v", reports)
+				}
+				return
+			}
+			if len(reports) != 1 {
+				t.Fatalf("expected one report, got %v", reports)
+			}
+			if reports[0].cmd != tt.cmd.Name() || reports[0].d < tt.duration {
+				t.Errorf("expected %s taking at least %v, got %+v", tt.cmd.Name(), tt.duration, reports[0])
+			}
+		})
+	}
+
+	t.Run("slow pipeline", func(t *testing.T) {
+		reports = nil
+		next := func(context.Context, []redis.Cmder) error {
+			time.Sleep(40 * time.Millisecond)
+			return nil
+		}
+		if err := hook.ProcessPipelineHook(next)(ctx, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(reports) != 1 || reports[0].cmd != "pipeline" {
+			t.Errorf("expected one pipeline report, got %v", reports)
+		}
+	})
+}
+
+// TestHooks tests installing user hooks
+func TestHooks(t *testing.T) {
+	t.Run("nil client records the hook", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		client.AddHook(commandRecorder(func(string) {}))
+		if len(client.hooks) != 1 {
+			t.Errorf("expected the hook to be recorded, got %d", len(client.hooks))
+		}
+	})
+
+	newTestClient(t) // skips without Redis
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	seen := map[string][]string{}
+	recorder := func(source string) redis.Hook {
+		return commandRecorder(func(name string) {
+			if name != "ping" {
+				return // ignore connection setup commands
+			}
+			mu.Lock()
+			seen[source] = append(seen[source], name)
+			mu.Unlock()
+		})
+	}
+	reset := func() map[string][]string {
+		mu.Lock()
+		defer mu.Unlock()
+		got := seen
+		seen = map[string][]string{}
+		return got
+	}
+
+	cfg := DefaultConfig()
+	cfg.Hooks = []redis.Hook{recorder("config")}
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+	client.AddHook(recorder("added"))
+
+	reset()
+	client.Ping(ctx)
+	if got := reset(); len(got["config"]) != 1 || len(got["added"]) != 1 {
+		t.Errorf("expected both hooks to see the ping, got %v", got)
+	}
+
+	next := DefaultConfig()
+	next.Hooks = []redis.Hook{recorder("reconfigured")}
+	if err := client.Reconfigure(next); err != nil {
+		t.Fatalf("Reconfigure: %v", err)
+	}
+	reset()
+	client.Ping(ctx)
+	got := reset()
+	if len(got["reconfigured"]) != 1 || len(got["added"]) != 1 {
+		t.Errorf("expected the new config's hooks and AddHook's to survive Reconfigure, got %v", got)
+	}
+	if len(got["config"]) != 0 {
+		t.Errorf("expected the old config's hooks to be dropped, got %v", got)
+	}
+}
@@ -0,0 +1,44 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// resultSuffix is appended to an idempotency key to name the key holding
+// the original request's result
+const resultSuffix = ":result"
+
+// Once marks key as seen for ttl and reports whether this call was the
+// first to do so, making it a guard for deduplicating retried requests.
+// Unlike Lock there is nothing to release: the key simply expires.
+// WriteTimeout applies when ctx has no deadline.
+func (c *Client) Once(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return false, ErrNilClient
+	}
+	if ttl <= 0 {
+		return false, fmt.Errorf("%w: ttl must be greater than 0", ErrInvalidArgument)
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	return st.rdb.SetNX(ctx, key, time.Now().UnixMilli(), ttl).Result()
+}
+
+// StoreResult caches the result of the request guarded by the idempotency
+// key as JSON for ttl, so duplicates can return it with FetchResult
+func (c *Client) StoreResult(ctx context.Context, key string, result any, ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("%w: ttl must be greater than 0", ErrInvalidArgument)
+	}
+	return c.SetJSON(ctx, key+resultSuffix, result, ttl)
+}
+
+// FetchResult decodes the result stored with StoreResult for the
+// idempotency key into dest. It returns ErrCacheMiss while the original
+// request has not stored one yet.
+func (c *Client) FetchResult(ctx context.Context, key string, dest any) error {
+	return c.GetJSON(ctx, key+resultSuffix, dest)
+}
@@ -0,0 +1,107 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestOnce tests deduplicating requests with idempotency keys
+func TestOnce(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.Once(context.Background(), "k", time.Second); err != ErrNilClient {
+			t.Errorf("Once: expected ErrNilClient, got %v", err)
+		}
+		if err := client.StoreResult(context.Background(), "k", 1, time.Second); err != ErrNilClient {
+			t.Errorf("StoreResult: expected ErrNilClient, got %v", err)
+		}
+		var v int
+		if err := client.FetchResult(context.Background(), "k", &v); err != ErrNilClient {
+			t.Errorf("FetchResult: expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.Once(context.Background(), "k", 0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Once: expected ErrInvalidArgument, got %v", err)
+		}
+		if err := client.StoreResult(context.Background(), "k", 1, -time.Second); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("StoreResult: expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	type response struct {
+		Status int    `json:"status"`
+		Body   string `json:"body"`
+	}
+
+	t.Run("first caller wins and duplicates see its result", func(t *testing.T) {
+		key := "rediskit:test:once:payment"
+		client.Del(ctx, key, key+resultSuffix)
+		defer client.Del(ctx, key, key+resultSuffix)
+
+		var winners atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				first, err := client.Once(ctx, key, time.Minute)
+				if err != nil {
+					t.Errorf("Once: %v", err)
+				}
+				if first {
+					winners.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		if n := winners.Load(); n != 1 {
+			t.Fatalf("expected exactly one first caller, got %d", n)
+		}
+
+		var got response
+		if err := client.FetchResult(ctx, key, &got); !errors.Is(err, ErrCacheMiss) {
+			t.Errorf("expected ErrCacheMiss before the result is stored, got %v", err)
+		}
+
+		want := response{Status: 201, Body: "created"}
+		if err := client.StoreResult(ctx, key, want, time.Minute); err != nil {
+			t.Fatalf("StoreResult: %v", err)
+		}
+		if err := client.FetchResult(ctx, key, &got); err != nil {
+			t.Fatalf("FetchResult: %v", err)
+		}
+		if got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+	})
+
+	t.Run("key expires with the ttl", func(t *testing.T) {
+		key := "rediskit:test:once:expiry"
+		client.Del(ctx, key)
+		defer client.Del(ctx, key)
+
+		if first, err := client.Once(ctx, key, time.Minute); err != nil || !first {
+			t.Fatalf("expected first call to win, got %v, %v", first, err)
+		}
+		if first, _ := client.Once(ctx, key, time.Minute); first {
+			t.Error("expected a duplicate within the TTL")
+		}
+		if ttl := client.PTTL(ctx, key).Val(); ttl <= 0 || ttl > time.Minute {
+			t.Errorf("expected a TTL of up to a minute, got %v", ttl)
+		}
+	})
+}
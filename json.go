@@ -0,0 +1,387 @@
+package rediskit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DecodeErrorPolicy decides what GetJSON and MGetJSON do with a stored
+// value that cannot be decoded, e.g. after corruption or schema drift
+type DecodeErrorPolicy string
+
+const (
+	DecodeErrorFail          DecodeErrorPolicy = "fail"   // return the decode error, the default
+	DecodeErrorTreatAsMiss   DecodeErrorPolicy = "miss"   // report the key as missing so callers recompute it
+	DecodeErrorDeleteAndMiss DecodeErrorPolicy = "delete" // delete the bad value, then report a miss
+)
+
+// validDecodeErrorPolicy reports whether p names a known policy
+func validDecodeErrorPolicy(p DecodeErrorPolicy) bool {
+	switch p {
+	case "", DecodeErrorFail, DecodeErrorTreatAsMiss, DecodeErrorDeleteAndMiss:
+		return true
+	}
+	return false
+}
+
+// setOptions holds the conditions of a SetJSON write
+type setOptions struct {
+	mode    string // "NX", "XX", or empty
+	keepTTL bool
+}
+
+// SetOption configures a SetJSON write
+type SetOption func(*setOptions)
+
+// SetNX only writes the value if the key does not exist yet
+func SetNX() SetOption {
+	return func(o *setOptions) { o.mode = "NX" }
+}
+
+// SetXX only writes the value if the key already exists
+func SetXX() SetOption {
+	return func(o *setOptions) { o.mode = "XX" }
+}
+
+// KeepTTL keeps the key's current expiry instead of applying ttl, which
+// must then be 0. Requires Redis 6.0.
+func KeepTTL() SetOption {
+	return func(o *setOptions) { o.keepTTL = true }
+}
+
+// SetJSON stores value at key encoded as JSON, with ttl as its expiry (0 for
+// none). Struct fields tagged rediskit:"encrypt" are encrypted with
+// EncryptionKey, and the value is compressed when Compression is set and
+// it reaches CompressionThreshold. opts make the write conditional with
+// SetNX or SetXX, or keep the current expiry with KeepTTL; a skipped
+// conditional write is not an error, use SetJSONNX to learn whether it
+// happened. WriteTimeout applies when ctx has no deadline, and
+// ErrCircuitOpen is returned without a round trip while the circuit is
+// open. With EnableTracing set a write adds a cache.set event to the span
+// in ctx.
+func (c *Client) SetJSON(ctx context.Context, key string, value any, ttl time.Duration, opts ...SetOption) error {
+	_, err := c.setJSON(ctx, key, value, ttl, opts)
+	return err
+}
+
+// SetJSONNX stores value at key like SetJSON only if the key does not
+// exist yet, reporting whether it was written, for set-if-absent cache
+// fills
+func (c *Client) SetJSONNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	return c.setJSON(ctx, key, value, ttl, []SetOption{SetNX()})
+}
+
+// setJSON implements SetJSON, reporting whether the value was written
+func (c *Client) setJSON(ctx context.Context, key string, value any, ttl time.Duration, opts []SetOption) (bool, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return false, ErrNilClient
+	}
+	var o setOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.keepTTL && ttl != 0 {
+		return false, fmt.Errorf("%w: ttl must be 0 with KeepTTL", ErrInvalidArgument)
+	}
+	data, err := c.encodeJSON(key, value)
+	if err != nil {
+		return false, err
+	}
+	done, err := c.circuitGuard("SetJSON")
+	if err != nil {
+		return false, err
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	err = st.rdb.SetArgs(ctx, key, data, redis.SetArgs{Mode: o.mode, TTL: ttl, KeepTTL: o.keepTTL}).Err()
+	done(err)
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err == nil {
+		addCacheEvent(c.cacheSpan(ctx), "cache.set", key, ttl)
+	}
+	return err == nil, err
+}
+
+// GetJSON decodes the JSON value stored at key into dest, decompressing it
+// and decrypting fields tagged rediskit:"encrypt". It returns ErrCacheMiss
+// when the key does not exist and ErrDecryptFailed when an encrypted field
+// cannot be decrypted. A value that fails to decode is handled as
+// DecodeErrorPolicy says, which can turn the error into ErrCacheMiss.
+// ReadTimeout applies when ctx has no deadline, and the circuit breaker as
+// for SetJSON. With EnableTracing set it adds a cache.hit or cache.miss
+// event to the span in ctx.
+func (c *Client) GetJSON(ctx context.Context, key string, dest any) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	done, err := c.circuitGuard("GetJSON")
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.readContext(ctx)
+	defer cancel()
+	span := c.cacheSpan(ctx)
+	data, ttl, err := getWithTTL(ctx, st.rdb, key, span)
+	done(err)
+	if errors.Is(err, redis.Nil) {
+		addCacheEvent(span, "cache.miss", key, -1)
+		return ErrCacheMiss
+	}
+	if err != nil {
+		return err
+	}
+	if err := c.decodeJSON(key, data, dest); err != nil {
+		err = c.decodeFailed(ctx, key, data, err)
+		if errors.Is(err, ErrCacheMiss) {
+			addCacheEvent(span, "cache.miss", key, -1)
+		}
+		return err
+	}
+	addCacheEvent(span, "cache.hit", key, ttl)
+	return nil
+}
+
+// GetDelJSON atomically deletes key and decodes the value it held into
+// dest, as GetJSON does, so that only one caller can consume it. It
+// returns ErrCacheMiss when the key does not exist. WriteTimeout applies
+// when ctx has no deadline, and the circuit breaker as for SetJSON.
+// Requires Redis 6.2.
+func (c *Client) GetDelJSON(ctx context.Context, key string, dest any) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	done, err := c.circuitGuard("GetDelJSON")
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	data, err := st.rdb.GetDel(ctx, key).Bytes()
+	done(err)
+	if errors.Is(err, redis.Nil) {
+		return ErrCacheMiss
+	}
+	if err != nil {
+		return err
+	}
+	return c.decodeJSON(key, data, dest)
+}
+
+// GetSetJSON atomically replaces the value at key with newValue, encoded
+// as SetJSON does, and decodes the previous value into dest. The key loses
+// any expiry it had. newValue is stored even when the key did not exist,
+// in which case ErrCacheMiss is returned and dest is left untouched.
+// WriteTimeout applies when ctx has no deadline, and the circuit breaker
+// as for SetJSON.
+func (c *Client) GetSetJSON(ctx context.Context, key string, newValue, dest any) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	data, err := c.encodeJSON(key, newValue)
+	if err != nil {
+		return err
+	}
+	done, err := c.circuitGuard("GetSetJSON")
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	old, err := st.rdb.GetSet(ctx, key, data).Bytes()
+	done(err)
+	if errors.Is(err, redis.Nil) {
+		return ErrCacheMiss
+	}
+	if err != nil {
+		return err
+	}
+	return c.decodeJSON(key, old, dest)
+}
+
+// encodeJSON encodes value for storage at key, encrypting tagged fields
+// and compressing the result as configured.
+func (c *Client) encodeJSON(key string, value any) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encode %q: %w", key, err)
+	}
+	if data, err = c.encryptFields(value, data); err != nil {
+		return nil, fmt.Errorf("encrypt %q: %w", key, err)
+	}
+	if data, err = c.compress(data); err != nil {
+		return nil, fmt.Errorf("compress %q: %w", key, err)
+	}
+	return data, nil
+}
+
+// decodeFailed applies DecodeErrorPolicy to err, the failure to decode
+// data read from key, returning either err or ErrCacheMiss. The delete
+// policy only deletes key while it still holds data, so a value rewritten
+// in the meantime survives.
+func (c *Client) decodeFailed(ctx context.Context, key string, data []byte, err error) error {
+	st := c.load()
+	policy := st.config.DecodeErrorPolicy
+	if policy != DecodeErrorTreatAsMiss && policy != DecodeErrorDeleteAndMiss {
+		return err
+	}
+	logger := st.config.Logger
+	if policy == DecodeErrorDeleteAndMiss {
+		if _, delErr := c.DeleteIfEquals(ctx, key, string(data)); delErr != nil && logger != nil {
+			logger.Warnf("deleting undecodable value at %q failed: %v", key, delErr)
+		}
+	}
+	if logger != nil {
+		logger.Warnf("discarding undecodable value at %q: %v", key, err)
+	}
+	return ErrCacheMiss
+}
+
+// decodeJSON reverses encodeJSON, decoding the value read from key into dest.
+func (c *Client) decodeJSON(key string, data []byte, dest any) error {
+	data, err := decompress(data)
+	if err != nil {
+		return fmt.Errorf("decompress %q: %w", key, err)
+	}
+	if data, err = c.decryptFields(dest, data); err != nil {
+		return fmt.Errorf("decrypt %q: %w", key, err)
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("decode %q: %w", key, err)
+	}
+	return nil
+}
+
+// MSetJSON stores every value in pairs encoded as JSON, like SetJSON, with
+// ttl as each key's expiry (0 for none). Every value is encoded before
+// anything is written, and the writes are pipelined in chunks for large
+// maps. WriteTimeout bounds the whole call when ctx has no deadline, and
+// the circuit breaker applies as for SetJSON.
+func (c *Client) MSetJSON(ctx context.Context, pairs map[string]any, ttl time.Duration) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if ttl < 0 {
+		return fmt.Errorf("%w: ttl must not be negative", ErrInvalidArgument)
+	}
+
+	encoded := make(map[string][]byte, len(pairs))
+	for key, value := range pairs {
+		data, err := c.encodeJSON(key, value)
+		if err != nil {
+			return err
+		}
+		encoded[key] = data
+	}
+	if len(encoded) == 0 {
+		return nil
+	}
+	done, err := c.circuitGuard("MSetJSON")
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	err = msetEncoded(ctx, st.rdb, encoded, ttl)
+	done(err)
+	return err
+}
+
+// msetEncoded writes encoded values in pipelined chunks
+func msetEncoded(ctx context.Context, rdb *redis.Client, encoded map[string][]byte, ttl time.Duration) error {
+	pipe := rdb.Pipeline()
+	for key, data := range encoded {
+		pipe.Set(ctx, key, data, ttl)
+		if pipe.Len() == pipelineChunkSize {
+			if _, err := pipe.Exec(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	if pipe.Len() > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MGetJSON decodes the JSON values stored at keys into dest with a single
+// MGET, returning the keys that do not exist. dest must point to a slice,
+// which is replaced by one with an element per key, zero for missing keys,
+// or to a map with string keys, which gains an entry per found key. Under
+// the miss policies of DecodeErrorPolicy, keys whose values fail to decode
+// are returned as missing. ReadTimeout applies when ctx has no deadline,
+// and the circuit breaker as for SetJSON.
+func (c *Client) MGetJSON(ctx context.Context, keys []string, dest any) ([]string, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return nil, fmt.Errorf("%w: dest must be a non-nil pointer to a slice or map", ErrInvalidArgument)
+	}
+	target := rv.Elem()
+	switch {
+	case target.Kind() == reflect.Slice:
+		target.Set(reflect.MakeSlice(target.Type(), len(keys), len(keys)))
+	case target.Kind() == reflect.Map && target.Type().Key().Kind() == reflect.String:
+		if target.IsNil() {
+			target.Set(reflect.MakeMap(target.Type()))
+		}
+	default:
+		return nil, fmt.Errorf("%w: dest must point to a slice or a map with string keys, got %T", ErrInvalidArgument, dest)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	done, err := c.circuitGuard("MGetJSON")
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.readContext(ctx)
+	defer cancel()
+	vals, err := st.rdb.MGet(ctx, keys...).Result()
+	done(err)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	elemType := target.Type().Elem()
+	for i, val := range vals {
+		s, ok := val.(string)
+		if !ok {
+			missing = append(missing, keys[i])
+			continue
+		}
+		elem := reflect.New(elemType)
+		if err := c.decodeJSON(keys[i], []byte(s), elem.Interface()); err != nil {
+			if err := c.decodeFailed(ctx, keys[i], []byte(s), err); !errors.Is(err, ErrCacheMiss) {
+				return nil, err
+			}
+			missing = append(missing, keys[i])
+			continue
+		}
+		if target.Kind() == reflect.Slice {
+			target.Index(i).Set(elem.Elem())
+		} else {
+			target.SetMapIndex(reflect.ValueOf(keys[i]).Convert(target.Type().Key()), elem.Elem())
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,449 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestJSON tests storing and loading JSON values
+func TestJSON(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.SetJSON(context.Background(), "k", 1, 0); err != ErrNilClient {
+			t.Errorf("SetJSON: expected ErrNilClient, got %v", err)
+		}
+		var v int
+		if err := client.GetJSON(context.Background(), "k", &v); err != ErrNilClient {
+			t.Errorf("GetJSON: expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("unencodable value", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if err := client.SetJSON(context.Background(), "k", make(chan int), 0); err == nil {
+			t.Error("expected encode error")
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	type user struct {
+		ID    int      `json:"id"`
+		Name  string   `json:"name"`
+		Roles []string `json:"roles"`
+	}
+
+	tests := []struct {
+		name  string
+		value any
+		dest  func() any
+	}{
+		{
+			name:  "struct",
+			value: user{ID: 1, Name: "ada", Roles: []string{"admin"}},
+			dest:  func() any { return &user{} },
+		},
+		{
+			name:  "map",
+			value: map[string]int{"a": 1, "b": 2},
+			dest:  func() any { return &map[string]int{} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := "rediskit:test:json:" + tt.name
+			defer client.Del(ctx, key)
+
+			if err := client.SetJSON(ctx, key, tt.value, time.Minute); err != nil {
+				t.Fatalf("SetJSON: %v", err)
+			}
+			dest := tt.dest()
+			if err := client.GetJSON(ctx, key, dest); err != nil {
+				t.Fatalf("GetJSON: %v", err)
+			}
+			if got := reflect.ValueOf(dest).Elem().Interface(); !reflect.DeepEqual(got, tt.value) {
+				t.Errorf("expected %+v, got %+v", tt.value, got)
+			}
+			if ttl := client.TTL(ctx, key).Val(); ttl <= 0 {
+				t.Errorf("expected a TTL, got %v", ttl)
+			}
+		})
+	}
+
+	t.Run("miss", func(t *testing.T) {
+		key := "rediskit:test:json:missing"
+		client.Del(ctx, key)
+		var u user
+		err := client.GetJSON(ctx, key, &u)
+		if !errors.Is(err, ErrCacheMiss) {
+			t.Errorf("expected ErrCacheMiss, got %v", err)
+		}
+		if !errors.Is(err, redis.Nil) {
+			t.Errorf("expected ErrCacheMiss to wrap redis.Nil, got %v", err)
+		}
+	})
+
+	t.Run("decode failure is not a miss", func(t *testing.T) {
+		key := "rediskit:test:json:invalid"
+		defer client.Del(ctx, key)
+		client.Set(ctx, key, "not json", 0)
+		var u user
+		err := client.GetJSON(ctx, key, &u)
+		if err == nil || errors.Is(err, ErrCacheMiss) {
+			t.Errorf("expected decode error, got %v", err)
+		}
+	})
+
+	t.Run("decode error policy", func(t *testing.T) {
+		key := "rediskit:test:json:corrupt"
+		defer client.Del(ctx, key)
+
+		tests := []struct {
+			policy   DecodeErrorPolicy
+			wantMiss bool
+			wantKept bool
+		}{
+			{DecodeErrorFail, false, true},
+			{DecodeErrorTreatAsMiss, true, true},
+			{DecodeErrorDeleteAndMiss, true, false},
+		}
+		for _, tt := range tests {
+			t.Run(string(tt.policy), func(t *testing.T) {
+				logger := &recordingLogger{}
+				cfg := *client.GetConfig()
+				cfg.DecodeErrorPolicy = tt.policy
+				cfg.Logger = logger
+				policied := clientWith(client.Client, &cfg)
+				client.Set(ctx, key, "not json", 0)
+
+				var u user
+				err := policied.GetJSON(ctx, key, &u)
+				if got := errors.Is(err, ErrCacheMiss); got != tt.wantMiss || err == nil {
+					t.Errorf("expected miss %v, got %v", tt.wantMiss, err)
+				}
+				if kept := client.Exists(ctx, key).Val() == 1; kept != tt.wantKept {
+					t.Errorf("expected value kept %v, got %v", tt.wantKept, kept)
+				}
+				logged := len(logger.lines()) == 1 && strings.Contains(logger.lines()[0], key)
+				if logged != tt.wantMiss {
+					t.Errorf("expected the discarded key logged %v, got %q", tt.wantMiss, logger.lines())
+				}
+
+				client.Set(ctx, key, "not json", 0)
+				var us []user
+				missing, err := policied.MGetJSON(ctx, []string{key}, &us)
+				if tt.wantMiss && (err != nil || len(missing) != 1) {
+					t.Errorf("MGetJSON: expected the key reported missing, got %v, %v", missing, err)
+				}
+				if !tt.wantMiss && err == nil {
+					t.Error("MGetJSON: expected a decode error")
+				}
+			})
+		}
+
+		cfg := DefaultConfig()
+		cfg.DecodeErrorPolicy = "ignore"
+		if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig for an unknown policy, got %v", err)
+		}
+	})
+}
+
+// TestMJSON tests storing and loading JSON values in bulk
+func TestMJSON(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.MSetJSON(context.Background(), map[string]any{"k": 1}, 0); err != ErrNilClient {
+			t.Errorf("MSetJSON: expected ErrNilClient, got %v", err)
+		}
+		var v []int
+		if _, err := client.MGetJSON(context.Background(), []string{"k"}, &v); err != ErrNilClient {
+			t.Errorf("MGetJSON: expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid dest", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		var n int
+		var byInt map[int]string
+		for _, dest := range []any{nil, []int{}, &n, &byInt, (*[]int)(nil)} {
+			if _, err := client.MGetJSON(context.Background(), []string{"k"}, dest); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("dest %T: expected ErrInvalidArgument, got %v", dest, err)
+			}
+		}
+		if err := client.MSetJSON(context.Background(), nil, -time.Second); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("negative ttl: expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	prefix := "rediskit:test:mjson:"
+	pairs := map[string]any{
+		prefix + "a": item{ID: 1, Name: "a"},
+		prefix + "b": item{ID: 2, Name: "b"},
+	}
+	keys := []string{prefix + "a", prefix + "missing", prefix + "b"}
+	defer client.Del(ctx, keys...)
+
+	if err := client.MSetJSON(ctx, pairs, time.Minute); err != nil {
+		t.Fatalf("MSetJSON: %v", err)
+	}
+	if ttl := client.TTL(ctx, prefix+"a").Val(); ttl <= 0 {
+		t.Errorf("expected a TTL, got %v", ttl)
+	}
+
+	t.Run("slice with partial hits", func(t *testing.T) {
+		items := []item{{ID: 99}}
+		missing, err := client.MGetJSON(ctx, keys, &items)
+		if err != nil {
+			t.Fatalf("MGetJSON: %v", err)
+		}
+		want := []item{{ID: 1, Name: "a"}, {}, {ID: 2, Name: "b"}}
+		if !reflect.DeepEqual(items, want) {
+			t.Errorf("expected %+v, got %+v", want, items)
+		}
+		if !reflect.DeepEqual(missing, []string{prefix + "missing"}) {
+			t.Errorf("expected the missing key to be reported, got %v", missing)
+		}
+	})
+
+	t.Run("map with partial hits", func(t *testing.T) {
+		var items map[string]*item
+		missing, err := client.MGetJSON(ctx, keys, &items)
+		if err != nil {
+			t.Fatalf("MGetJSON: %v", err)
+		}
+		if len(items) != 2 || items[prefix+"a"].Name != "a" || items[prefix+"b"].ID != 2 {
+			t.Errorf("unexpected map %+v", items)
+		}
+		if len(missing) != 1 {
+			t.Errorf("expected one missing key, got %v", missing)
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		if err := client.MSetJSON(ctx, nil, time.Minute); err != nil {
+			t.Errorf("MSetJSON: %v", err)
+		}
+		items := []item{{ID: 1}}
+		missing, err := client.MGetJSON(ctx, nil, &items)
+		if err != nil || missing != nil || len(items) != 0 {
+			t.Errorf("expected an empty result, got %v, %v, %v", items, missing, err)
+		}
+	})
+
+	t.Run("decode error", func(t *testing.T) {
+		key := prefix + "bad"
+		defer client.Del(ctx, key)
+		client.Set(ctx, key, "not json", time.Minute)
+		var items []item
+		if _, err := client.MGetJSON(ctx, []string{key}, &items); err == nil {
+			t.Error("expected a decode error")
+		}
+	})
+}
+
+// TestGetDelSetJSON tests atomically consuming and swapping JSON values
+func TestGetDelSetJSON(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		var v int
+		if err := client.GetDelJSON(context.Background(), "k", &v); err != ErrNilClient {
+			t.Errorf("GetDelJSON: expected ErrNilClient, got %v", err)
+		}
+		if err := client.GetSetJSON(context.Background(), "k", 1, &v); err != ErrNilClient {
+			t.Errorf("GetSetJSON: expected ErrNilClient, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	const prefix = "rediskit:test:getdeljson:"
+
+	type token struct {
+		ID    string `json:"id"`
+		Scope string `json:"scope"`
+	}
+
+	t.Run("GetDelJSON returns and removes the value", func(t *testing.T) {
+		key := prefix + "token"
+		defer client.Del(ctx, key)
+		want := token{ID: "t1", Scope: "login"}
+		if err := client.SetJSON(ctx, key, want, time.Minute); err != nil {
+			t.Fatalf("SetJSON: %v", err)
+		}
+
+		var got token
+		if err := client.GetDelJSON(ctx, key, &got); err != nil {
+			t.Fatalf("GetDelJSON: %v", err)
+		}
+		if got != want {
+			t.Errorf("expected %+v, got %+v", want, got)
+		}
+		if n := client.Exists(ctx, key).Val(); n != 0 {
+			t.Error("expected the key to be deleted")
+		}
+		if err := client.GetDelJSON(ctx, key, &got); !errors.Is(err, ErrCacheMiss) {
+			t.Errorf("expected ErrCacheMiss on second read, got %v", err)
+		}
+	})
+
+	t.Run("GetSetJSON swaps the value", func(t *testing.T) {
+		key := prefix + "rotate"
+		defer client.Del(ctx, key)
+		old := token{ID: "t1"}
+		next := token{ID: "t2"}
+		if err := client.SetJSON(ctx, key, old, 0); err != nil {
+			t.Fatalf("SetJSON: %v", err)
+		}
+
+		var got token
+		if err := client.GetSetJSON(ctx, key, next, &got); err != nil {
+			t.Fatalf("GetSetJSON: %v", err)
+		}
+		if got != old {
+			t.Errorf("expected previous value %+v, got %+v", old, got)
+		}
+		var stored token
+		if err := client.GetJSON(ctx, key, &stored); err != nil || stored != next {
+			t.Errorf("expected %+v to be stored, got %+v, %v", next, stored, err)
+		}
+	})
+
+	t.Run("GetSetJSON on a missing key stores the value", func(t *testing.T) {
+		key := prefix + "fresh"
+		client.Del(ctx, key)
+		defer client.Del(ctx, key)
+
+		var got token
+		if err := client.GetSetJSON(ctx, key, token{ID: "t1"}, &got); !errors.Is(err, ErrCacheMiss) {
+			t.Errorf("expected ErrCacheMiss, got %v", err)
+		}
+		if got != (token{}) {
+			t.Errorf("expected dest to be untouched, got %+v", got)
+		}
+		var stored token
+		if err := client.GetJSON(ctx, key, &stored); err != nil || stored.ID != "t1" {
+			t.Errorf("expected the new value to be stored, got %+v, %v", stored, err)
+		}
+	})
+}
+
+// TestSetJSONOptions tests conditional JSON writes
+func TestSetJSONOptions(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.SetJSONNX(context.Background(), "k", 1, 0); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("KeepTTL with a ttl", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if err := client.SetJSON(context.Background(), "k", 1, time.Minute, KeepTTL()); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:setjsonopts"
+	defer client.Del(ctx, key)
+
+	get := func(t *testing.T) string {
+		t.Helper()
+		var v string
+		if err := client.GetJSON(ctx, key, &v); err != nil {
+			t.Fatalf("GetJSON: %v", err)
+		}
+		return v
+	}
+
+	t.Run("NX writes an absent key", func(t *testing.T) {
+		client.Del(ctx, key)
+		written, err := client.SetJSONNX(ctx, key, "first", time.Minute)
+		if err != nil || !written {
+			t.Fatalf("expected the write to happen, got %v, %v", written, err)
+		}
+		if v := get(t); v != "first" {
+			t.Errorf("expected first, got %q", v)
+		}
+	})
+
+	t.Run("NX skips a present key", func(t *testing.T) {
+		written, err := client.SetJSONNX(ctx, key, "second", time.Minute)
+		if err != nil || written {
+			t.Fatalf("expected the write to be skipped, got %v, %v", written, err)
+		}
+		if err := client.SetJSON(ctx, key, "third", time.Minute, SetNX()); err != nil {
+			t.Fatalf("SetJSON with SetNX: %v", err)
+		}
+		if v := get(t); v != "first" {
+			t.Errorf("expected the value to be kept, got %q", v)
+		}
+	})
+
+	t.Run("XX only overwrites", func(t *testing.T) {
+		if err := client.SetJSON(ctx, key, "replaced", time.Minute, SetXX()); err != nil {
+			t.Fatalf("SetJSON with SetXX: %v", err)
+		}
+		if v := get(t); v != "replaced" {
+			t.Errorf("expected replaced, got %q", v)
+		}
+		client.Del(ctx, key)
+		if err := client.SetJSON(ctx, key, "created", time.Minute, SetXX()); err != nil {
+			t.Fatalf("SetJSON with SetXX: %v", err)
+		}
+		if n := client.Exists(ctx, key).Val(); n != 0 {
+			t.Error("expected SetXX not to create the key")
+		}
+	})
+
+	t.Run("KeepTTL preserves the expiry", func(t *testing.T) {
+		if err := client.SetJSON(ctx, key, "v1", time.Hour); err != nil {
+			t.Fatalf("SetJSON: %v", err)
+		}
+		if err := client.SetJSON(ctx, key, "v2", 0, KeepTTL()); err != nil {
+			t.Fatalf("SetJSON with KeepTTL: %v", err)
+		}
+		if v := get(t); v != "v2" {
+			t.Errorf("expected v2, got %q", v)
+		}
+		if ttl := client.TTL(ctx, key).Val(); ttl <= 59*time.Minute {
+			t.Errorf("expected the hour-long TTL to be kept, got %v", ttl)
+		}
+
+		if err := client.SetJSON(ctx, key, "v3", 0); err != nil {
+			t.Fatalf("SetJSON: %v", err)
+		}
+		if ttl := client.TTL(ctx, key).Val(); ttl != -1 {
+			t.Errorf("expected a plain write to clear the TTL, got %v", ttl)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package rediskit
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// KeySeparator joins the parts of keys built by BuildKey
+const KeySeparator = ":"
+
+// BuildKey joins parts with KeySeparator and prepends KeyPrefix, so keys
+// are built the same way everywhere. Parts must be non-empty and free of
+// the separator, whitespace, and control characters, so that distinct
+// parts can never produce the same key; a bad part is reported by index
+// in an ErrInvalidArgument error.
+func (c *Client) BuildKey(parts ...string) (string, error) {
+	st := c.load()
+	if len(parts) == 0 {
+		return "", fmt.Errorf("%w: key needs at least one part", ErrInvalidArgument)
+	}
+	for i, part := range parts {
+		if part == "" {
+			return "", fmt.Errorf("%w: key part %d is empty", ErrInvalidArgument, i)
+		}
+		if strings.Contains(part, KeySeparator) {
+			return "", fmt.Errorf("%w: key part %d %q contains the separator %q", ErrInvalidArgument, i, part, KeySeparator)
+		}
+		for _, r := range part {
+			if unicode.IsSpace(r) || unicode.IsControl(r) {
+				return "", fmt.Errorf("%w: key part %d %q contains whitespace or control character %q", ErrInvalidArgument, i, part, r)
+			}
+		}
+	}
+	return st.config.KeyPrefix + strings.Join(parts, KeySeparator), nil
+}
@@ -0,0 +1,55 @@
+package rediskit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestBuildKey tests building validated keys
+func TestBuildKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		parts   []string
+		want    string
+		wantErr string
+	}{
+		{name: "single part", parts: []string{"users"}, want: "users"},
+		{name: "joins parts", parts: []string{"user", "42", "profile"}, want: "user:42:profile"},
+		{name: "applies prefix", prefix: "app:", parts: []string{"user", "42"}, want: "app:user:42"},
+		{name: "unicode is allowed", parts: []string{"city", "zürich"}, want: "city:zürich"},
+		{name: "no parts", wantErr: "at least one part"},
+		{name: "empty part", parts: []string{"user", ""}, wantErr: "part 1 is empty"},
+		{name: "separator", parts: []string{"user", "42:admin"}, wantErr: `part 1 "42:admin" contains the separator`},
+		{name: "space", parts: []string{"user name"}, wantErr: `part 0 "user name" contains whitespace`},
+		{name: "tab", parts: []string{"a", "b\tc"}, wantErr: "part 1"},
+		{name: "newline", parts: []string{"a\n"}, wantErr: "part 0"},
+		{name: "control character", parts: []string{"a\x00b"}, wantErr: "control character"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.KeyPrefix = tt.prefix
+			client := clientWith(nil, cfg)
+
+			got, err := client.BuildKey(tt.parts...)
+			if tt.wantErr != "" {
+				if !errors.Is(err, ErrInvalidArgument) {
+					t.Fatalf("expected ErrInvalidArgument, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error to contain %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
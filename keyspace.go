@@ -0,0 +1,147 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// defaultScanBatchSize is the SCAN COUNT hint used when a caller passes none
+const defaultScanBatchSize = 100
+
+// MoveByPattern moves every key matching pattern, with KeyPrefix prepended
+// as ScanKeys does, from the client's database to destDB, scanning and
+// moving batchSize keys per round trip. Keys that already exist in destDB
+// are left in place, since MOVE refuses them. It returns the number of
+// keys moved, including on error.
+func (c *Client) MoveByPattern(ctx context.Context, pattern string, destDB int, batchSize int) (int, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return 0, ErrNilClient
+	}
+	if destDB < 0 {
+		return 0, fmt.Errorf("%w: destination db must not be negative", ErrInvalidArgument)
+	}
+	if destDB == st.config.DB {
+		return 0, fmt.Errorf("%w: destination db is the source db", ErrInvalidArgument)
+	}
+	if batchSize <= 0 {
+		batchSize = defaultScanBatchSize
+	}
+	if pattern == "" {
+		pattern = "*"
+	}
+	pattern = st.config.KeyPrefix + pattern
+
+	moved := 0
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return moved, err
+		}
+
+		keys, next, err := st.rdb.Scan(ctx, cursor, pattern, int64(batchSize)).Result()
+		if err != nil {
+			return moved, err
+		}
+
+		if len(keys) > 0 {
+			pipe := st.rdb.Pipeline()
+			moves := make([]*redis.BoolCmd, len(keys))
+			for i, key := range keys {
+				moves[i] = pipe.Move(ctx, key, destDB)
+			}
+			if _, err := pipe.Exec(ctx); err != nil {
+				return moved, err
+			}
+			for _, m := range moves {
+				if m.Val() {
+					moved++
+				}
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return moved, nil
+		}
+	}
+}
+
+// coldKeyScanPause is the pause between SCAN batches in FindColdKeys, to
+// keep a long analysis from monopolizing the server
+const coldKeyScanPause = 10 * time.Millisecond
+
+// KeyInfo describes a key found by a keyspace analysis
+type KeyInfo struct {
+	Key      string
+	IdleTime time.Duration
+}
+
+// FindColdKeys scans keys matching pattern, with KeyPrefix prepended as
+// ScanKeys does, samples roughly sampleRate of them (0 < sampleRate <= 1),
+// and returns those whose OBJECT IDLETIME is at least idleThreshold. Keys
+// are returned as stored, including the prefix. It pauses between batches
+// and stops when ctx is done. Idle times are not tracked under an LFU
+// maxmemory policy.
+func (c *Client) FindColdKeys(ctx context.Context, pattern string, idleThreshold time.Duration, sampleRate float64) ([]KeyInfo, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	if sampleRate <= 0 || sampleRate > 1 {
+		return nil, fmt.Errorf("%w: sample rate must be in (0, 1]", ErrInvalidArgument)
+	}
+	if pattern == "" {
+		pattern = "*"
+	}
+	pattern = st.config.KeyPrefix + pattern
+
+	var cold []KeyInfo
+	var cursor uint64
+	for {
+		keys, next, err := st.rdb.Scan(ctx, cursor, pattern, defaultScanBatchSize).Result()
+		if err != nil {
+			return cold, err
+		}
+
+		pipe := st.rdb.Pipeline()
+		var sampled []string
+		var idles []*redis.DurationCmd
+		for _, key := range keys {
+			if sampleRate < 1 && rand.Float64() >= sampleRate {
+				continue
+			}
+			sampled = append(sampled, key)
+			idles = append(idles, pipe.ObjectIdleTime(ctx, key))
+		}
+		if len(sampled) > 0 {
+			if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+				return cold, err
+			}
+			for i, idle := range idles {
+				if idle.Err() != nil {
+					continue // deleted since it was scanned
+				}
+				if idle.Val() >= idleThreshold {
+					cold = append(cold, KeyInfo{Key: sampled[i], IdleTime: idle.Val()})
+				}
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return cold, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return cold, ctx.Err()
+		case <-time.After(coldKeyScanPause):
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestMoveByPattern tests moving matching keys to another database
+func TestMoveByPattern(t *testing.T) {
+	t.Run("invalid arguments", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		if _, err := client.MoveByPattern(context.Background(), "*", -1, 10); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("negative db: expected ErrInvalidArgument, got %v", err)
+		}
+		if _, err := client.MoveByPattern(context.Background(), "*", 0, 10); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("same db: expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("with valid client", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+
+		destCfg := DefaultConfig()
+		destCfg.DB = 1
+		dest, err := NewClient(destCfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer dest.Close()
+
+		keys := []string{"rediskit:test:move:a", "rediskit:test:move:b", "rediskit:test:move:c"}
+		defer client.Del(ctx, keys...)
+		defer dest.Del(ctx, keys...)
+		for _, key := range keys {
+			client.Set(ctx, key, "src", 0)
+		}
+		// Already present in the destination, so MOVE must skip it
+		dest.Set(ctx, keys[2], "dest", 0)
+
+		moved, err := client.MoveByPattern(ctx, "rediskit:test:move:*", 1, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if moved != 2 {
+			t.Errorf("moved %d keys, want 2", moved)
+		}
+
+		if n := client.Exists(ctx, keys[0], keys[1]).Val(); n != 0 {
+			t.Errorf("expected moved keys to leave the source db, %d remain", n)
+		}
+		if v := dest.Get(ctx, keys[0]).Val(); v != "src" {
+			t.Errorf("expected moved value in destination, got %q", v)
+		}
+		if v := client.Get(ctx, keys[2]).Val(); v != "src" {
+			t.Errorf("expected skipped key to stay in source, got %q", v)
+		}
+		if v := dest.Get(ctx, keys[2]).Val(); v != "dest" {
+			t.Errorf("expected destination value untouched, got %q", v)
+		}
+	})
+
+	t.Run("pattern gets the key prefix", func(t *testing.T) {
+		base := newTestClient(t)
+		cfg := *base.GetConfig()
+		cfg.KeyPrefix = "rediskit:test:moveprefix:"
+		client := clientWith(base.Client, &cfg)
+		ctx := context.Background()
+
+		destCfg := DefaultConfig()
+		destCfg.DB = 1
+		dest, err := NewClient(destCfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer dest.Close()
+
+		inside, outside := cfg.KeyPrefix+"a", "rediskit:test:moveother:a"
+		defer client.Del(ctx, inside, outside)
+		defer dest.Del(ctx, inside, outside)
+		client.Set(ctx, inside, "v", 0)
+		client.Set(ctx, outside, "v", 0)
+
+		moved, err := client.MoveByPattern(ctx, "*", 1, 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if moved != 1 {
+			t.Errorf("moved %d keys, want 1", moved)
+		}
+		if n := dest.Exists(ctx, inside).Val(); n != 1 {
+			t.Errorf("expected %q in the destination", inside)
+		}
+		if n := client.Exists(ctx, outside).Val(); n != 1 {
+			t.Errorf("expected %q, outside the prefix, to stay", outside)
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := client.MoveByPattern(ctx, "rediskit:test:move:*", 1, 10); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+// TestFindColdKeys tests sampling keys by idle time
+func TestFindColdKeys(t *testing.T) {
+	t.Run("invalid sample rate", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		for _, rate := range []float64{0, -0.5, 1.5} {
+			if _, err := client.FindColdKeys(context.Background(), "*", time.Minute, rate); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("rate %v: expected ErrInvalidArgument, got %v", rate, err)
+			}
+		}
+	})
+
+	t.Run("with valid client", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+		keys := []string{"rediskit:test:cold:a", "rediskit:test:cold:b"}
+		defer client.Del(ctx, keys...)
+		for _, key := range keys {
+			client.Set(ctx, key, "v", 0)
+		}
+
+		found, err := client.FindColdKeys(ctx, "rediskit:test:cold:*", 0, 1)
+		if err != nil {
+			t.Skipf("OBJECT IDLETIME not available: %v", err)
+		}
+		if len(found) != len(keys) {
+			t.Errorf("expected %d keys with zero threshold, got %v", len(keys), found)
+		}
+
+		found, err = client.FindColdKeys(ctx, "rediskit:test:cold:*", time.Hour, 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(found) != 0 {
+			t.Errorf("expected no keys idle for an hour, got %v", found)
+		}
+	})
+
+	t.Run("pattern gets the key prefix", func(t *testing.T) {
+		base := newTestClient(t)
+		cfg := *base.GetConfig()
+		cfg.KeyPrefix = "rediskit:test:coldprefix:"
+		client := clientWith(base.Client, &cfg)
+		ctx := context.Background()
+
+		inside, outside := cfg.KeyPrefix+"a", "rediskit:test:coldother:a"
+		defer client.Del(ctx, inside, outside)
+		client.Set(ctx, inside, "v", 0)
+		client.Set(ctx, outside, "v", 0)
+
+		found, err := client.FindColdKeys(ctx, "*", 0, 1)
+		if err != nil {
+			t.Skipf("OBJECT IDLETIME not available: %v", err)
+		}
+		if len(found) != 1 || found[0].Key != inside {
+			t.Errorf("expected only %q, got %v", inside, found)
+		}
+	})
+}
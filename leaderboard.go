@@ -0,0 +1,84 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RankedMember is a leaderboard entry with its zero-based rank, where rank
+// 0 has the highest score
+type RankedMember struct {
+	Member string
+	Score  float64
+	Rank   int64
+}
+
+// LeaderboardAdd sets member's score on the leaderboard stored as the
+// sorted set key, adding the member if it is new
+func (c *Client) LeaderboardAdd(ctx context.Context, key, member string, score float64) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	return st.rdb.ZAdd(ctx, key, redis.Z{Score: score, Member: member}).Err()
+}
+
+// LeaderboardTop returns the n highest-scoring members of the leaderboard
+// at key, best first. Members with equal scores are ordered by member in
+// reverse lexicographic order, as ZREVRANGE does, and get distinct ranks.
+// It returns ErrReplyTooLarge when n exceeds MaxReplyElements.
+func (c *Client) LeaderboardTop(ctx context.Context, key string, n int64) ([]RankedMember, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: n must be greater than 0", ErrInvalidArgument)
+	}
+	if err := c.checkReplySize(n); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.readContext(ctx)
+	defer cancel()
+	zs, err := st.rdb.ZRevRangeWithScores(ctx, key, 0, n-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	top := make([]RankedMember, len(zs))
+	for i, z := range zs {
+		member, _ := z.Member.(string)
+		top[i] = RankedMember{Member: member, Score: z.Score, Rank: int64(i)}
+	}
+	return top, nil
+}
+
+// LeaderboardRank returns member's zero-based rank and score on the
+// leaderboard at key, ordered as LeaderboardTop orders it. It returns
+// ErrCacheMiss when member is not on the leaderboard.
+func (c *Client) LeaderboardRank(ctx context.Context, key, member string) (int64, float64, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return 0, 0, ErrNilClient
+	}
+	ctx, cancel := c.readContext(ctx)
+	defer cancel()
+	var rank *redis.IntCmd
+	var score *redis.FloatCmd
+	_, err := st.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		rank = pipe.ZRevRank(ctx, key, member)
+		score = pipe.ZScore(ctx, key, member)
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return 0, 0, ErrCacheMiss
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return rank.Val(), score.Val(), nil
+}
@@ -0,0 +1,123 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestLeaderboard tests ranking members of a sorted set
+func TestLeaderboard(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		ctx := context.Background()
+		if err := client.LeaderboardAdd(ctx, "k", "m", 1); err != ErrNilClient {
+			t.Errorf("LeaderboardAdd: expected ErrNilClient, got %v", err)
+		}
+		if _, err := client.LeaderboardTop(ctx, "k", 1); err != ErrNilClient {
+			t.Errorf("LeaderboardTop: expected ErrNilClient, got %v", err)
+		}
+		if _, _, err := client.LeaderboardRank(ctx, "k", "m"); err != ErrNilClient {
+			t.Errorf("LeaderboardRank: expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid n", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.LeaderboardTop(context.Background(), "k", 0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("n above MaxReplyElements", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.MaxReplyElements = 10
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.LeaderboardTop(context.Background(), "k", 11); !errors.Is(err, ErrReplyTooLarge) {
+			t.Errorf("expected ErrReplyTooLarge, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:leaderboard"
+	client.Del(ctx, key)
+	defer client.Del(ctx, key)
+
+	scores := map[string]float64{"ada": 50, "bob": 80, "cy": 80, "dee": 20}
+	for member, score := range scores {
+		if err := client.LeaderboardAdd(ctx, key, member, score); err != nil {
+			t.Fatalf("LeaderboardAdd: %v", err)
+		}
+	}
+
+	t.Run("top is ordered by score with ties in reverse member order", func(t *testing.T) {
+		top, err := client.LeaderboardTop(ctx, key, 3)
+		if err != nil {
+			t.Fatalf("LeaderboardTop: %v", err)
+		}
+		want := []RankedMember{
+			{Member: "cy", Score: 80, Rank: 0},
+			{Member: "bob", Score: 80, Rank: 1},
+			{Member: "ada", Score: 50, Rank: 2},
+		}
+		if !reflect.DeepEqual(top, want) {
+			t.Errorf("expected %+v, got %+v", want, top)
+		}
+	})
+
+	t.Run("n beyond the size returns every member", func(t *testing.T) {
+		top, err := client.LeaderboardTop(ctx, key, 100)
+		if err != nil {
+			t.Fatalf("LeaderboardTop: %v", err)
+		}
+		if len(top) != len(scores) {
+			t.Errorf("expected %d members, got %d", len(scores), len(top))
+		}
+	})
+
+	tests := []struct {
+		member string
+		rank   int64
+		score  float64
+	}{
+		{"cy", 0, 80},
+		{"bob", 1, 80},
+		{"dee", 3, 20},
+	}
+	for _, tt := range tests {
+		t.Run("rank of "+tt.member, func(t *testing.T) {
+			rank, score, err := client.LeaderboardRank(ctx, key, tt.member)
+			if err != nil {
+				t.Fatalf("LeaderboardRank: %v", err)
+			}
+			if rank != tt.rank || score != tt.score {
+				t.Errorf("expected rank %d score %v, got rank %d score %v", tt.rank, tt.score, rank, score)
+			}
+		})
+	}
+
+	t.Run("updating a score reorders", func(t *testing.T) {
+		if err := client.LeaderboardAdd(ctx, key, "dee", 100); err != nil {
+			t.Fatalf("LeaderboardAdd: %v", err)
+		}
+		if rank, _, _ := client.LeaderboardRank(ctx, key, "dee"); rank != 0 {
+			t.Errorf("expected dee to lead, got rank %d", rank)
+		}
+	})
+
+	t.Run("missing member", func(t *testing.T) {
+		if _, _, err := client.LeaderboardRank(ctx, key, "nobody"); !errors.Is(err, ErrCacheMiss) {
+			t.Errorf("expected ErrCacheMiss, got %v", err)
+		}
+	})
+}
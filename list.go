@@ -0,0 +1,141 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// listRangeChunk is the number of elements ListRange fetches per LRANGE
+const listRangeChunk = 1000
+
+// ListRange returns the elements of the list at key between start and stop,
+// inclusive, with the same index semantics as LRANGE. When MaxReplyElements
+// is set, the range is checked against it before anything is fetched and
+// read in chunks, so a huge list cannot be pulled into memory by accident.
+func (c *Client) ListRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	ctx, cancel := c.readContext(ctx)
+	defer cancel()
+	if st.config.MaxReplyElements == 0 {
+		return st.rdb.LRange(ctx, key, start, stop).Result()
+	}
+
+	length, err := st.rdb.LLen(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	start, stop = normalizeRange(start, stop, length)
+	if start > stop {
+		return []string{}, nil
+	}
+	if err := c.checkReplySize(stop - start + 1); err != nil {
+		return nil, err
+	}
+
+	out := make([]string, 0, stop-start+1)
+	for from := start; from <= stop; from += listRangeChunk {
+		to := min(from+listRangeChunk-1, stop)
+		vals, err := st.rdb.LRange(ctx, key, from, to).Result()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vals...)
+		if int64(len(vals)) < to-from+1 {
+			break // the list shrank underneath us
+		}
+	}
+	return out, nil
+}
+
+// checkReplySize returns ErrReplyTooLarge when n exceeds MaxReplyElements
+func (c *Client) checkReplySize(n int64) error {
+	st := c.load()
+	if limit := st.config.MaxReplyElements; limit > 0 && n > int64(limit) {
+		return fmt.Errorf("%w: %d elements, limit is %d", ErrReplyTooLarge, n, limit)
+	}
+	return nil
+}
+
+// normalizeRange resolves negative Redis range indices against length and
+// clamps them to the valid range. An empty range has start > stop.
+func normalizeRange(start, stop, length int64) (int64, int64) {
+	if start < 0 {
+		start += length
+	}
+	if stop < 0 {
+		stop += length
+	}
+	start = max(start, 0)
+	stop = min(stop, length-1)
+	return start, stop
+}
+
+// PopN atomically pops up to n elements from the head of the list at key.
+// It returns fewer elements when the list is shorter and an empty slice when
+// the list is empty or missing, and ErrReplyTooLarge when n exceeds
+// MaxReplyElements. Servers older than Redis 6.2, which lack LPOP with a
+// count, get up to n pipelined LPOPs in a transaction instead.
+func (c *Client) PopN(ctx context.Context, key string, n int64) ([]string, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: pop count must be greater than 0", ErrInvalidArgument)
+	}
+	if err := c.checkReplySize(n); err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+
+	vals, err := st.rdb.LPopCount(ctx, key, int(n)).Result()
+	switch {
+	case errors.Is(err, redis.Nil):
+		return []string{}, nil
+	case isWrongArity(err):
+		return c.popNFallback(ctx, key, n)
+	case err != nil:
+		return nil, err
+	}
+	return vals, nil
+}
+
+// popNFallback pops up to n elements with individual LPOPs inside
+// MULTI/EXEC. It sends no more LPOPs than the list held when checked, so
+// a huge n does not allocate a command per requested element.
+func (c *Client) popNFallback(ctx context.Context, key string, n int64) ([]string, error) {
+	st := c.load()
+	length, err := st.rdb.LLen(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	n = min(n, length)
+	if n == 0 {
+		return []string{}, nil
+	}
+
+	pipe := st.rdb.TxPipeline()
+	pops := make([]*redis.StringCmd, n)
+	for i := range pops {
+		pops[i] = pipe.LPop(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	vals := make([]string, 0, n)
+	for _, pop := range pops {
+		if pop.Err() != nil {
+			break
+		}
+		vals = append(vals, pop.Val())
+	}
+	return vals, nil
+}
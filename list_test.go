@@ -0,0 +1,225 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestNormalizeRange tests resolution of Redis range indices
+func TestNormalizeRange(t *testing.T) {
+	tests := []struct {
+		start, stop, length int64
+		wantStart, wantStop int64
+	}{
+		{0, -1, 10, 0, 9},
+		{-3, -1, 10, 7, 9},
+		{2, 100, 10, 2, 9},
+		{-100, 3, 10, 0, 3},
+		{5, 2, 10, 5, 2},
+		{0, -1, 0, 0, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d..%d of %d", tt.start, tt.stop, tt.length), func(t *testing.T) {
+			start, stop := normalizeRange(tt.start, tt.stop, tt.length)
+			if start != tt.wantStart || stop != tt.wantStop {
+				t.Errorf("got %d..%d, want %d..%d", start, stop, tt.wantStart, tt.wantStop)
+			}
+		})
+	}
+}
+
+// TestListRange tests range reads with and without a reply limit
+func TestListRange(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.ListRange(context.Background(), "k", 0, -1); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("negative limit is invalid", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.MaxReplyElements = -1
+		if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:list"
+	client.Del(ctx, key)
+	defer client.Del(ctx, key)
+
+	items := make([]any, 2500)
+	for i := range items {
+		items[i] = i
+	}
+	if err := client.RPush(ctx, key, items...).Err(); err != nil {
+		t.Fatalf("RPush: %v", err)
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+		vals, err := client.ListRange(ctx, key, 0, -1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(vals) != len(items) {
+			t.Errorf("got %d elements, want %d", len(vals), len(items))
+		}
+	})
+
+	limited, err := NewClient(&Config{
+		Host:             "localhost",
+		Port:             "6379",
+		PoolSize:         10,
+		DefaultTimeout:   5 * time.Second,
+		MaxReplyElements: 2000,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer limited.Close()
+
+	t.Run("over the limit", func(t *testing.T) {
+		if _, err := limited.ListRange(ctx, key, 0, -1); !errors.Is(err, ErrReplyTooLarge) {
+			t.Errorf("expected ErrReplyTooLarge, got %v", err)
+		}
+	})
+
+	t.Run("within the limit is read in chunks", func(t *testing.T) {
+		vals, err := limited.ListRange(ctx, key, 100, 2099)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(vals) != 2000 {
+			t.Fatalf("got %d elements, want 2000", len(vals))
+		}
+		if vals[0] != "100" || vals[1999] != "2099" {
+			t.Errorf("unexpected bounds %q..%q", vals[0], vals[1999])
+		}
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		vals, err := limited.ListRange(ctx, "rediskit:test:list:missing", 0, -1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(vals) != 0 {
+			t.Errorf("expected no elements, got %d", len(vals))
+		}
+	})
+}
+
+// TestPopN tests popping batches from a list
+func TestPopN(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.PopN(context.Background(), "k", 1); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid count", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.PopN(context.Background(), "k", 0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:popn"
+	client.Del(ctx, key)
+	defer client.Del(ctx, key)
+
+	client.RPush(ctx, key, "a", "b", "c")
+
+	t.Run("pops up to n", func(t *testing.T) {
+		vals, err := client.PopN(ctx, key, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(vals) != "[a b]" {
+			t.Errorf("got %v, want [a b]", vals)
+		}
+	})
+
+	t.Run("returns fewer when short", func(t *testing.T) {
+		vals, err := client.PopN(ctx, key, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(vals) != "[c]" {
+			t.Errorf("got %v, want [c]", vals)
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		vals, err := client.PopN(ctx, key, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if vals == nil || len(vals) != 0 {
+			t.Errorf("expected empty slice, got %#v", vals)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		client.RPush(ctx, key, "x", "y", "z")
+		vals, err := client.popNFallback(ctx, key, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(vals) != "[x y]" {
+			t.Errorf("got %v, want [x y]", vals)
+		}
+		vals, err = client.popNFallback(ctx, key, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(vals) != "[z]" {
+			t.Errorf("got %v, want [z]", vals)
+		}
+	})
+
+	t.Run("fallback sends no more pops than the list holds", func(t *testing.T) {
+		client.RPush(ctx, key, "p", "q")
+		vals, err := client.popNFallback(ctx, key, math.MaxInt64)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(vals) != "[p q]" {
+			t.Errorf("got %v, want [p q]", vals)
+		}
+		vals, err = client.popNFallback(ctx, key, math.MaxInt64)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if vals == nil || len(vals) != 0 {
+			t.Errorf("expected empty slice, got %#v", vals)
+		}
+	})
+
+	t.Run("count above MaxReplyElements", func(t *testing.T) {
+		cfg := *client.GetConfig()
+		cfg.MaxReplyElements = 10
+		limited := clientWith(client.Client, &cfg)
+		client.RPush(ctx, key, "r")
+		if _, err := limited.PopN(ctx, key, 11); !errors.Is(err, ErrReplyTooLarge) {
+			t.Errorf("expected ErrReplyTooLarge, got %v", err)
+		}
+		if n := client.LLen(ctx, key).Val(); n != 1 {
+			t.Errorf("expected the list untouched, got length %d", n)
+		}
+	})
+}
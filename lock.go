@@ -0,0 +1,178 @@
+package rediskit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// refreshLockScript sets the TTL in ARGV[2] (milliseconds) on KEYS[1] only
+// if it holds the token in ARGV[1]
+var refreshLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// Lock is a held distributed lock. Only the holder's random token can
+// release or extend it.
+type Lock struct {
+	client *Client
+	key    string
+	token  string
+
+	mu     sync.Mutex
+	expiry *time.Timer // drops the lock from client.locks once its ttl passes
+}
+
+// Lock acquires a lock on key with SET NX PX, expiring after ttl unless
+// refreshed. It returns ErrLockNotAcquired when another owner holds it.
+func (c *Client) Lock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	return c.acquireLock(ctx, st.rdb, key, ttl)
+}
+
+// LockReplicated acquires a lock like Lock, then blocks with WAIT until at
+// least replicas replicas have acknowledged the write, so a failover to one
+// of them cannot lose the lock. Each acquisition costs an extra round trip
+// plus the replication lag, up to timeout. If too few replicas acknowledge
+// in time, the lock is released and ErrNotReplicated is returned.
+func (c *Client) LockReplicated(ctx context.Context, key string, ttl time.Duration, replicas int, timeout time.Duration) (*Lock, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	if replicas <= 0 {
+		return nil, fmt.Errorf("%w: replicas must be greater than 0", ErrInvalidArgument)
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("%w: timeout must be greater than 0", ErrInvalidArgument)
+	}
+
+	// WAIT only covers writes made on the same connection
+	cn := st.rdb.Conn()
+	defer cn.Close()
+
+	lock, err := c.acquireLock(ctx, cn, key, ttl)
+	if err != nil {
+		return nil, err
+	}
+	waitCtx, cancel := c.blockingContext(ctx, timeout)
+	acked, err := cn.Wait(waitCtx, replicas, timeout).Result()
+	cancel()
+	if err == nil && acked < int64(replicas) {
+		err = fmt.Errorf("%w: %d of %d replicas acknowledged the lock", ErrNotReplicated, acked, replicas)
+	}
+	if err != nil {
+		lock.Unlock(ctx)
+		return nil, err
+	}
+	return lock, nil
+}
+
+// acquireLock runs the SET NX PX for a new lock on cmd
+func (c *Client) acquireLock(ctx context.Context, cmd redis.Cmdable, key string, ttl time.Duration) (*Lock, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("%w: ttl must be greater than 0", ErrInvalidArgument)
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("generate lock token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	ok, err := cmd.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLockNotAcquired
+	}
+	lock := &Lock{client: c, key: key, token: token}
+	lock.track(ttl)
+	return lock, nil
+}
+
+// Key returns the locked key
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Unlock releases the lock if it is still held by this owner. It returns
+// ErrLockNotHeld when the lock expired or was taken over, in which case the
+// key is left untouched.
+func (l *Lock) Unlock(ctx context.Context) error {
+	ctx, cancel := l.client.writeContext(ctx)
+	defer cancel()
+	deleted, err := l.client.DeleteIfEquals(ctx, l.key, l.token)
+	if err != nil {
+		return err
+	}
+	l.untrack()
+	if !deleted {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Refresh atomically resets the lock's expiry to ttl if it is still held by
+// this owner, returning ErrLockNotHeld otherwise. A lock found not held is
+// no longer released by Shutdown.
+func (l *Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	if ttl <= 0 {
+		return fmt.Errorf("%w: ttl must be greater than 0", ErrInvalidArgument)
+	}
+	rdb := l.client.load().rdb
+	if rdb == nil {
+		return ErrNilClient
+	}
+	ctx, cancel := l.client.writeContext(ctx)
+	defer cancel()
+	n, err := refreshLockScript.Run(ctx, rdb, []string{l.key}, l.token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		l.untrack()
+		return ErrLockNotHeld
+	}
+	l.track(ttl)
+	return nil
+}
+
+// track records l in client.locks, for Shutdown to release, until ttl
+// passes and the lock expires on the server anyway. The stored value is
+// the deadline in Unix nanoseconds.
+func (l *Lock) track(ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.expiry != nil {
+		l.expiry.Stop()
+	}
+	deadline := time.Now().Add(ttl).UnixNano()
+	l.client.locks.Store(l, deadline)
+	// A timer that fires as a refresh replaces it leaves the new entry alone
+	l.expiry = time.AfterFunc(ttl, func() { l.client.locks.CompareAndDelete(l, deadline) })
+}
+
+// untrack removes l from client.locks
+func (l *Lock) untrack() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.expiry != nil {
+		l.expiry.Stop()
+	}
+	l.client.locks.Delete(l)
+}
@@ -0,0 +1,188 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestLock tests acquiring, refreshing, and releasing distributed locks
+func TestLock(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.Lock(context.Background(), "k", time.Second); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.Lock(context.Background(), "k", 0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:lock"
+	client.Del(ctx, key)
+	defer client.Del(ctx, key)
+
+	t.Run("contention", func(t *testing.T) {
+		first, err := client.Lock(ctx, key, time.Minute)
+		if err != nil {
+			t.Fatalf("first Lock: %v", err)
+		}
+		if first.Key() != key {
+			t.Errorf("expected key %q, got %q", key, first.Key())
+		}
+
+		if _, err := client.Lock(ctx, key, time.Minute); !errors.Is(err, ErrLockNotAcquired) {
+			t.Fatalf("second Lock: expected ErrLockNotAcquired, got %v", err)
+		}
+
+		if err := first.Unlock(ctx); err != nil {
+			t.Fatalf("Unlock: %v", err)
+		}
+		second, err := client.Lock(ctx, key, time.Minute)
+		if err != nil {
+			t.Fatalf("Lock after release: %v", err)
+		}
+		second.Unlock(ctx)
+	})
+
+	t.Run("refresh extends the ttl", func(t *testing.T) {
+		lock, err := client.Lock(ctx, key, time.Second)
+		if err != nil {
+			t.Fatalf("Lock: %v", err)
+		}
+		defer lock.Unlock(ctx)
+
+		if err := lock.Refresh(ctx, time.Hour); err != nil {
+			t.Fatalf("Refresh: %v", err)
+		}
+		if ttl := client.PTTL(ctx, key).Val(); ttl <= time.Minute {
+			t.Errorf("expected ttl to be extended, got %v", ttl)
+		}
+		if err := lock.Refresh(ctx, 0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument for zero ttl, got %v", err)
+		}
+	})
+
+	t.Run("token mismatch", func(t *testing.T) {
+		lock, err := client.Lock(ctx, key, time.Minute)
+		if err != nil {
+			t.Fatalf("Lock: %v", err)
+		}
+
+		// Simulate the lock expiring and another owner taking it over
+		client.Set(ctx, key, "other-owner", time.Minute)
+
+		if err := lock.Unlock(ctx); !errors.Is(err, ErrLockNotHeld) {
+			t.Errorf("Unlock: expected ErrLockNotHeld, got %v", err)
+		}
+		if err := lock.Refresh(ctx, time.Minute); !errors.Is(err, ErrLockNotHeld) {
+			t.Errorf("Refresh: expected ErrLockNotHeld, got %v", err)
+		}
+		if v := client.Get(ctx, key).Val(); v != "other-owner" {
+			t.Errorf("expected other owner's lock to survive, got %q", v)
+		}
+	})
+
+	t.Run("tracking ends on expiry and failed refresh", func(t *testing.T) {
+		tracked := func(lock *Lock) bool {
+			_, ok := client.locks.Load(lock)
+			return ok
+		}
+		client.Del(ctx, key)
+
+		expiring, err := client.Lock(ctx, key, 20*time.Millisecond)
+		if err != nil {
+			t.Fatalf("Lock: %v", err)
+		}
+		if !tracked(expiring) {
+			t.Fatal("expected a new lock to be tracked")
+		}
+		time.Sleep(50 * time.Millisecond)
+		if tracked(expiring) {
+			t.Error("expected an expired lock to be dropped")
+		}
+		client.Del(ctx, key)
+
+		lost, err := client.Lock(ctx, key, time.Minute)
+		if err != nil {
+			t.Fatalf("Lock: %v", err)
+		}
+		client.Set(ctx, key, "other-owner", time.Minute)
+		if err := lost.Refresh(ctx, time.Minute); !errors.Is(err, ErrLockNotHeld) {
+			t.Fatalf("Refresh: expected ErrLockNotHeld, got %v", err)
+		}
+		if tracked(lost) {
+			t.Error("expected a lock that failed to refresh to be dropped")
+		}
+		client.Del(ctx, key)
+
+		refreshed, err := client.Lock(ctx, key, 20*time.Millisecond)
+		if err != nil {
+			t.Fatalf("Lock: %v", err)
+		}
+		defer refreshed.Unlock(ctx)
+		if err := refreshed.Refresh(ctx, time.Minute); err != nil {
+			t.Fatalf("Refresh: %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+		if !tracked(refreshed) {
+			t.Error("expected a refreshed lock to stay tracked past its first ttl")
+		}
+	})
+}
+
+// TestLockReplicated tests locks that wait for replica acknowledgement
+func TestLockReplicated(t *testing.T) {
+	t.Run("invalid arguments", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		tests := []struct {
+			name     string
+			replicas int
+			timeout  time.Duration
+		}{
+			{"no replicas", 0, time.Second},
+			{"no timeout", 1, 0},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := client.LockReplicated(context.Background(), "k", time.Second, tt.replicas, tt.timeout)
+				if !errors.Is(err, ErrInvalidArgument) {
+					t.Errorf("expected ErrInvalidArgument, got %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("insufficient replicas release the lock", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+		key := "rediskit:test:lock:replicated"
+		client.Del(ctx, key)
+		defer client.Del(ctx, key)
+
+		// The test server has no replicas, so WAIT reports zero acknowledgements
+		_, err := client.LockReplicated(ctx, key, time.Minute, 1, 50*time.Millisecond)
+		if !errors.Is(err, ErrNotReplicated) {
+			t.Fatalf("expected ErrNotReplicated, got %v", err)
+		}
+		if n := client.Exists(ctx, key).Val(); n != 0 {
+			t.Error("expected the unreplicated lock to be released")
+		}
+	})
+}
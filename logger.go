@@ -0,0 +1,35 @@
+package rediskit
+
+import (
+	"fmt"
+	"log"
+)
+
+// Logger receives the client's internal events, such as retries, health
+// transitions, and reconnects. Set Config.Logger to enable it; when nil,
+// nothing is logged and no log arguments are built.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// StdLogger is a Logger that writes to a standard library logger, or to the
+// log package's default logger when Logger is nil
+type StdLogger struct {
+	Logger *log.Logger
+}
+
+func (l StdLogger) Debugf(format string, args ...any) { l.output("DEBUG", format, args) }
+func (l StdLogger) Infof(format string, args ...any)  { l.output("INFO", format, args) }
+func (l StdLogger) Warnf(format string, args ...any)  { l.output("WARN", format, args) }
+func (l StdLogger) Errorf(format string, args ...any) { l.output("ERROR", format, args) }
+
+func (l StdLogger) output(level, format string, args []any) {
+	logger := l.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+	logger.Output(3, "rediskit: "+level+" "+fmt.Sprintf(format, args...))
+}
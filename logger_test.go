@@ -0,0 +1,96 @@
+package rediskit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingLogger is a Logger that records lines as "LEVEL message"
+type recordingLogger struct {
+	mu  sync.Mutex
+	got []string
+}
+
+func (l *recordingLogger) Debugf(format string, args ...any) { l.record("DEBUG", format, args) }
+func (l *recordingLogger) Infof(format string, args ...any)  { l.record("INFO", format, args) }
+func (l *recordingLogger) Warnf(format string, args ...any)  { l.record("WARN", format, args) }
+func (l *recordingLogger) Errorf(format string, args ...any) { l.record("ERROR", format, args) }
+
+func (l *recordingLogger) record(level, format string, args []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.got = append(l.got, level+" "+fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) lines() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.got...)
+}
+
+// TestStdLogger tests the standard library adapter
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := StdLogger{Logger: log.New(&buf, "", 0)}
+	logger.Debugf("a %d", 1)
+	logger.Infof("b")
+	logger.Warnf("c")
+	logger.Errorf("d: %v", errors.New("boom"))
+
+	want := "rediskit: DEBUG a 1\nrediskit: INFO b\nrediskit: WARN c\nrediskit: ERROR d: boom\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	t.Run("nil logger uses the default", func(t *testing.T) {
+		var buf bytes.Buffer
+		orig := log.Writer()
+		log.SetOutput(&buf)
+		defer log.SetOutput(orig)
+		StdLogger{}.Warnf("x")
+		if !strings.Contains(buf.String(), "rediskit: WARN x") {
+			t.Errorf("expected output on the default logger, got %q", buf.String())
+		}
+	})
+}
+
+// TestRetryLogging tests that retries are logged only with a logger set
+func TestRetryLogging(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxRetries = 2
+	cfg.MinRetryBackoff = 0
+	cfg.MaxRetryBackoff = 0
+	logger := &recordingLogger{}
+	cfg.Logger = logger
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	client.WithRetry(context.Background(), func(context.Context) error { return io.EOF })
+	lines := logger.lines()
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "DEBUG retrying") || !strings.HasPrefix(lines[2], "WARN giving up after 3 attempts") {
+		t.Errorf("unexpected log lines %q", lines)
+	}
+
+	t.Run("no allocations without a logger", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		ctx := context.Background()
+		fn := func(context.Context) error { return nil }
+		if n := testing.AllocsPerRun(100, func() { client.WithRetry(ctx, fn) }); n != 0 {
+			t.Errorf("expected no allocations, got %v", n)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package rediskit
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Manager hands out one client per logical database of the same server,
+// created on first use from a shared base config
+type Manager struct {
+	base *Config
+
+	mu      sync.Mutex
+	clients map[int]*Client
+	closed  bool
+}
+
+// NewManager returns a Manager whose clients copy base with DB overridden.
+// A nil base uses DefaultConfig. base is copied, so later changes to it do
+// not affect the manager; maps and slices in it are shared.
+func NewManager(base *Config) *Manager {
+	if base == nil {
+		base = DefaultConfig()
+	}
+	cfg := *base
+	return &Manager{base: &cfg, clients: make(map[int]*Client)}
+}
+
+// DB returns the client for database n, creating it on the first call.
+// Later calls for the same n return the same client. Config errors, such
+// as n beyond MaxDB, are returned by the call that would create the
+// client, and redis.ErrClosed once the manager is closed.
+func (m *Manager) DB(n int) (*Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil, redis.ErrClosed
+	}
+	if client, ok := m.clients[n]; ok {
+		return client, nil
+	}
+
+	cfg := *m.base
+	cfg.DB = n
+	client, err := NewClient(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	m.clients[n] = client
+	return client, nil
+}
+
+// Close closes every client the manager created, joining their errors.
+// The manager cannot be used afterwards.
+func (m *Manager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+
+	var errs []error
+	for _, client := range m.clients {
+		if err := client.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	m.clients = nil
+	return errors.Join(errs...)
+}
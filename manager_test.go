@@ -0,0 +1,129 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestManager tests caching one client per database
+func TestManager(t *testing.T) {
+	t.Run("caches clients per database", func(t *testing.T) {
+		m := NewManager(nil)
+		defer m.Close()
+
+		first, err := m.DB(1)
+		if err != nil {
+			t.Fatalf("DB(1): %v", err)
+		}
+		again, err := m.DB(1)
+		if err != nil {
+			t.Fatalf("DB(1): %v", err)
+		}
+		if first != again {
+			t.Error("expected the same client for repeated DB(1) calls")
+		}
+		other, err := m.DB(2)
+		if err != nil {
+			t.Fatalf("DB(2): %v", err)
+		}
+		if other == first {
+			t.Error("expected a different client for DB(2)")
+		}
+		if db := first.GetConfig().DB; db != 1 {
+			t.Errorf("expected DB 1, got %d", db)
+		}
+		if db := other.Options().DB; db != 2 {
+			t.Errorf("expected go-redis DB 2, got %d", db)
+		}
+	})
+
+	t.Run("base config is copied", func(t *testing.T) {
+		base := DefaultConfig()
+		base.PoolSize = 3
+		m := NewManager(base)
+		defer m.Close()
+		base.PoolSize = 0
+
+		client, err := m.DB(0)
+		if err != nil {
+			t.Fatalf("DB(0): %v", err)
+		}
+		if size := client.Options().PoolSize; size != 3 {
+			t.Errorf("expected the base pool size 3, got %d", size)
+		}
+		if base.DB != 0 {
+			t.Error("expected the base config to be left untouched")
+		}
+	})
+
+	t.Run("out of range database", func(t *testing.T) {
+		m := NewManager(nil)
+		defer m.Close()
+		if _, err := m.DB(16); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	t.Run("concurrent calls share one client", func(t *testing.T) {
+		m := NewManager(nil)
+		defer m.Close()
+
+		clients := make([]*Client, 20)
+		var wg sync.WaitGroup
+		for i := range clients {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				clients[i], _ = m.DB(3)
+			}(i)
+		}
+		wg.Wait()
+		for i, c := range clients {
+			if c == nil || c != clients[0] {
+				t.Fatalf("call %d returned a different client", i)
+			}
+		}
+	})
+
+	t.Run("close closes every client", func(t *testing.T) {
+		m := NewManager(nil)
+		a, _ := m.DB(0)
+		b, _ := m.DB(1)
+		if err := m.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		for _, c := range []*Client{a, b} {
+			if err := c.Ping(context.Background()).Err(); !errors.Is(err, redis.ErrClosed) {
+				t.Errorf("expected a closed client, got %v", err)
+			}
+		}
+		if _, err := m.DB(0); !errors.Is(err, redis.ErrClosed) {
+			t.Errorf("expected redis.ErrClosed after Close, got %v", err)
+		}
+		if err := m.Close(); err != nil {
+			t.Errorf("expected a second Close to be a no-op, got %v", err)
+		}
+	})
+
+	t.Run("clients reach their databases", func(t *testing.T) {
+		newTestClient(t) // skips without Redis
+		m := NewManager(nil)
+		defer m.Close()
+		ctx := context.Background()
+		key := "rediskit:test:manager"
+
+		db1, _ := m.DB(1)
+		db2, _ := m.DB(2)
+		defer db1.Del(ctx, key)
+		if err := db1.Set(ctx, key, "one", 0).Err(); err != nil {
+			t.Fatalf("SET: %v", err)
+		}
+		if n := db2.Exists(ctx, key).Val(); n != 0 {
+			t.Error("expected the key to be invisible from another database")
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// KeyMemory is a key and the number of bytes it and its value take up in
+// server memory, as reported by MEMORY USAGE
+type KeyMemory struct {
+	Key   string
+	Bytes int64
+}
+
+// KeyMemoryUsage returns the number of bytes key and its value take up in
+// server memory. It returns ErrCacheMiss if the key does not exist and
+// ErrMemoryUsageUnsupported if the server does not know MEMORY USAGE.
+func (c *Client) KeyMemoryUsage(ctx context.Context, key string) (int64, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return 0, ErrNilClient
+	}
+	n, err := st.rdb.MemoryUsage(ctx, key).Result()
+	if err != nil {
+		return 0, wrapMemoryUsageErr(err)
+	}
+	return n, nil
+}
+
+// TopMemoryKeys scans up to sampleCount keys matching match, with KeyPrefix
+// prepended as in ScanKeys, and returns them ordered by memory usage,
+// largest first. Keys that are deleted between the scan and the lookup are
+// skipped. The result is only as representative as the sample.
+func (c *Client) TopMemoryKeys(ctx context.Context, match string, sampleCount int) ([]KeyMemory, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	if sampleCount <= 0 {
+		return nil, fmt.Errorf("%w: sample count must be greater than 0", ErrInvalidArgument)
+	}
+
+	it, err := c.ScanKeys(ctx, match, 0)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{})
+	var keys []string
+	for len(keys) < sampleCount && it.Next() {
+		if _, ok := seen[it.Val()]; ok {
+			continue
+		}
+		seen[it.Val()] = struct{}{}
+		keys = append(keys, it.Val())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	usage := make([]KeyMemory, 0, len(keys))
+	for start := 0; start < len(keys); start += defaultScanBatchSize {
+		batch := keys[start:min(start+defaultScanBatchSize, len(keys))]
+		pipe := st.rdb.Pipeline()
+		cmds := make([]*redis.IntCmd, len(batch))
+		for i, key := range batch {
+			cmds[i] = pipe.MemoryUsage(ctx, key)
+		}
+		if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+			return nil, wrapMemoryUsageErr(err)
+		}
+		for i, cmd := range cmds {
+			n, err := cmd.Result()
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			if err != nil {
+				return nil, wrapMemoryUsageErr(err)
+			}
+			usage = append(usage, KeyMemory{Key: batch[i], Bytes: n})
+		}
+	}
+
+	sort.SliceStable(usage, func(i, j int) bool {
+		return usage[i].Bytes > usage[j].Bytes
+	})
+	return usage, nil
+}
+
+// wrapMemoryUsageErr maps redis.Nil to ErrCacheMiss and the error returned
+// for an unknown MEMORY command to ErrMemoryUsageUnsupported
+func wrapMemoryUsageErr(err error) error {
+	switch {
+	case errors.Is(err, redis.Nil):
+		return ErrCacheMiss
+	case isUnknownCommand(err) || isUnknownSubcommand(err):
+		return fmt.Errorf("%w: %v", ErrMemoryUsageUnsupported, err)
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestKeyMemoryUsage tests reading the memory usage of a single key
+func TestKeyMemoryUsage(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.KeyMemoryUsage(context.Background(), "key"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+		if _, err := client.TopMemoryKeys(context.Background(), "*", 10); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("with valid client", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+		key := "rediskit:test:memory:single"
+		client.Set(ctx, key, strings.Repeat("x", 1000), 0)
+		defer client.Del(ctx, key)
+
+		n, err := client.KeyMemoryUsage(ctx, key)
+		if err != nil {
+			t.Fatalf("KeyMemoryUsage: %v", err)
+		}
+		if n < 1000 {
+			t.Errorf("expected at least 1000 bytes, got %d", n)
+		}
+
+		if _, err := client.KeyMemoryUsage(ctx, "rediskit:test:memory:missing"); !errors.Is(err, ErrCacheMiss) {
+			t.Errorf("expected ErrCacheMiss, got %v", err)
+		}
+	})
+}
+
+// TestWrapMemoryUsageErr tests classification of MEMORY USAGE errors
+func TestWrapMemoryUsageErr(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"nil", nil, nil},
+		{"missing key", redis.Nil, ErrCacheMiss},
+		{"unknown command", serverError("ERR unknown command 'MEMORY', with args beginning with: 'USAGE' "), ErrMemoryUsageUnsupported},
+		{"unknown subcommand", serverError("ERR unknown subcommand 'USAGE'. Try MEMORY HELP."), ErrMemoryUsageUnsupported},
+		{"other error", serverError("ERR something else"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrapMemoryUsageErr(tt.err)
+			if tt.want == nil {
+				if got != tt.err {
+					t.Errorf("expected %v unchanged, got %v", tt.err, got)
+				}
+				return
+			}
+			if !errors.Is(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestTopMemoryKeys tests sampling keys and ordering them by memory usage
+func TestTopMemoryKeys(t *testing.T) {
+	t.Run("invalid sample count", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.TopMemoryKeys(context.Background(), "*", 0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("with valid client", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+		sizes := map[string]int{
+			"rediskit:test:memory:top:small":  10,
+			"rediskit:test:memory:top:large":  5000,
+			"rediskit:test:memory:top:medium": 500,
+			"rediskit:test:memory:top:gone":   10000,
+		}
+		for key, size := range sizes {
+			client.Set(ctx, key, strings.Repeat("x", size), 0)
+			defer client.Del(ctx, key)
+		}
+
+		// Delete a key after it has been scanned, before its usage is read
+		other := newTestClient(t)
+		client.AddHook(beforePipeline(func(ctx context.Context) {
+			other.Del(ctx, "rediskit:test:memory:top:gone")
+		}))
+
+		got, err := client.TopMemoryKeys(ctx, "rediskit:test:memory:top:*", 100)
+		if err != nil {
+			t.Fatalf("TopMemoryKeys: %v", err)
+		}
+		want := []string{
+			"rediskit:test:memory:top:large",
+			"rediskit:test:memory:top:medium",
+			"rediskit:test:memory:top:small",
+		}
+		if len(got) != len(want) {
+			t.Fatalf("expected keys %v, got %v", want, got)
+		}
+		for i, km := range got {
+			if km.Key != want[i] {
+				t.Errorf("position %d: expected %s, got %s", i, want[i], km.Key)
+			}
+			if i > 0 && km.Bytes > got[i-1].Bytes {
+				t.Errorf("expected descending sizes, got %v", got)
+			}
+		}
+	})
+}
+
+// beforePipeline runs a function before each pipeline is sent
+type beforePipeline func(ctx context.Context)
+
+func (h beforePipeline) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h beforePipeline) ProcessHook(next redis.ProcessHook) redis.ProcessHook { return next }
+
+func (h beforePipeline) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		h(ctx)
+		return next(ctx, cmds)
+	}
+}
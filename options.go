@@ -0,0 +1,51 @@
+package rediskit
+
+import "time"
+
+// Option overrides a field of DefaultConfig for NewClientWithOptions
+type Option func(*Config)
+
+// WithHost sets Host
+func WithHost(host string) Option {
+	return func(c *Config) { c.Host = host }
+}
+
+// WithPort sets Port
+func WithPort(port string) Option {
+	return func(c *Config) { c.Port = port }
+}
+
+// WithUsername sets Username
+func WithUsername(username string) Option {
+	return func(c *Config) { c.Username = username }
+}
+
+// WithPassword sets Password
+func WithPassword(password string) Option {
+	return func(c *Config) { c.Password = password }
+}
+
+// WithDB sets DB
+func WithDB(db int) Option {
+	return func(c *Config) { c.DB = db }
+}
+
+// WithPoolSize sets PoolSize
+func WithPoolSize(size int) Option {
+	return func(c *Config) { c.PoolSize = size }
+}
+
+// WithTimeout sets DefaultTimeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Config) { c.DefaultTimeout = timeout }
+}
+
+// NewClientWithOptions creates a client from DefaultConfig with opts
+// applied in order. The result is validated like any other config.
+func NewClientWithOptions(opts ...Option) (*Client, error) {
+	cfg := DefaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return NewClient(cfg)
+}
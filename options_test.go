@@ -0,0 +1,75 @@
+package rediskit
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestOptions tests that each option sets its config field
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		opt   Option
+		check func(*Config) any
+		want  any
+	}{
+		{name: "WithHost", opt: WithHost("redis.internal"), check: func(c *Config) any { return c.Host }, want: "redis.internal"},
+		{name: "WithPort", opt: WithPort("6380"), check: func(c *Config) any { return c.Port }, want: "6380"},
+		{name: "WithUsername", opt: WithUsername("app"), check: func(c *Config) any { return c.Username }, want: "app"},
+		{name: "WithPassword", opt: WithPassword("secret"), check: func(c *Config) any { return c.Password }, want: "secret"},
+		{name: "WithDB", opt: WithDB(3), check: func(c *Config) any { return c.DB }, want: 3},
+		{name: "WithPoolSize", opt: WithPoolSize(42), check: func(c *Config) any { return c.PoolSize }, want: 42},
+		{name: "WithTimeout", opt: WithTimeout(time.Minute), check: func(c *Config) any { return c.DefaultTimeout }, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.opt(cfg)
+			if got := tt.check(cfg); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+
+			// Only the targeted field changes
+			got := reflect.ValueOf(*cfg)
+			def := reflect.ValueOf(*DefaultConfig())
+			changed := 0
+			for i := 0; i < got.NumField(); i++ {
+				if !reflect.DeepEqual(got.Field(i).Interface(), def.Field(i).Interface()) {
+					changed++
+				}
+			}
+			if changed != 1 {
+				t.Errorf("expected exactly one field to change, got %d", changed)
+			}
+		})
+	}
+}
+
+// TestNewClientWithOptions tests building a client from options
+func TestNewClientWithOptions(t *testing.T) {
+	t.Run("options are applied", func(t *testing.T) {
+		client, err := NewClientWithOptions(WithDB(2), WithPoolSize(4), WithPoolSize(5))
+		if err != nil {
+			t.Fatalf("NewClientWithOptions: %v", err)
+		}
+		defer client.Close()
+		cfg := client.GetConfig()
+		if cfg.DB != 2 || cfg.PoolSize != 5 {
+			t.Errorf("expected DB 2 and the last pool size 5, got %d and %d", cfg.DB, cfg.PoolSize)
+		}
+		if cfg.Host != DefaultConfig().Host {
+			t.Errorf("expected default host, got %q", cfg.Host)
+		}
+	})
+
+	t.Run("validation still runs", func(t *testing.T) {
+		for _, opt := range []Option{WithHost(""), WithPoolSize(0), WithTimeout(0), WithDB(-1)} {
+			if _, err := NewClientWithOptions(opt); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("expected ErrInvalidConfig, got %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// credentials holds the username and password new connections authenticate
+// with, so RotatePassword can change them without rebuilding the pool
+type credentials struct {
+	mu       sync.RWMutex
+	username string
+	password string
+
+	installed bool // set once client options read from it
+}
+
+func newCredentials(cfg *Config) *credentials {
+	return &credentials{username: cfg.Username, password: cfg.Password}
+}
+
+// get returns the current username and password
+func (cr *credentials) get() (string, string) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	return cr.username, cr.password
+}
+
+// set replaces the username and password
+func (cr *credentials) set(username, password string) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.username, cr.password = username, password
+}
+
+// install makes opts read its credentials from cr, unless cr is nil or
+// OptionsHook already set a provider or changed the configured credentials
+func (cr *credentials) install(opts *redis.Options) {
+	if cr == nil {
+		return
+	}
+	if opts.CredentialsProvider != nil || opts.CredentialsProviderContext != nil || opts.StreamingCredentialsProvider != nil {
+		return
+	}
+	if username, password := cr.get(); opts.Username != username || opts.Password != password {
+		return
+	}
+	opts.CredentialsProvider = cr.get
+	cr.installed = true
+}
+
+// RotatePassword switches the client, and any replicas, to newPassword
+// without reconnecting. The password is first checked with AUTH on a
+// pooled connection to each server; if any check fails the error is
+// returned and the stored config is left unchanged. Otherwise the config
+// password is updated and new connections, including those made after
+// Reset, authenticate with it, while existing connections stay open and
+// authenticated. Clients created by NewFailoverClient, or whose
+// OptionsHook manages credentials, do not support rotation.
+func (c *Client) RotatePassword(ctx context.Context, newPassword string) error {
+	// Load the state under the lock so a concurrent Reconfigure is not undone
+	c.reconfigure.Lock()
+	defer c.reconfigure.Unlock()
+
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if st.creds == nil {
+		return fmt.Errorf("%w: failover clients do not support password rotation", ErrInvalidConfig)
+	}
+	if !st.creds.installed {
+		return fmt.Errorf("%w: credentials are managed by OptionsHook", ErrInvalidConfig)
+	}
+	username, _ := st.creds.get()
+	for _, rdb := range append([]*redis.Client{st.rdb}, st.replicas...) {
+		if err := checkPassword(ctx, rdb, username, newPassword); err != nil {
+			return fmt.Errorf("rotate password for %s: %w", rdb.Options().Addr, err)
+		}
+	}
+
+	st.creds.set(username, newPassword)
+	cfg := *st.config
+	cfg.Password = newPassword
+	c.state.Store(&clientState{rdb: st.rdb, config: &cfg, replicas: st.replicas, creds: st.creds})
+
+	if logger := cfg.Logger; logger != nil {
+		logger.Infof("rotated password for %s", cfg.target())
+	}
+	return nil
+}
+
+// checkPassword authenticates a pooled connection of rdb with username and
+// password
+func checkPassword(ctx context.Context, rdb *redis.Client, username, password string) error {
+	cn := rdb.Conn()
+	defer cn.Close()
+	if username == "" {
+		return cn.Auth(ctx, password).Err()
+	}
+	return cn.AuthACL(ctx, username, password).Err()
+}
@@ -0,0 +1,156 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestRotatePassword tests that the config password only changes after the
+// new one authenticates
+func TestRotatePassword(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.RotatePassword(context.Background(), "secret"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("credentials managed by options hook", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.OptionsHook = func(opts *redis.Options) {
+			opts.CredentialsProvider = func() (string, string) { return "", "" }
+		}
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		if err := client.RotatePassword(context.Background(), "secret"); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	t.Run("with fake auth", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+		client.AddHook(fakeAuth("new-secret"))
+
+		err := client.RotatePassword(ctx, "wrong-secret")
+		if err == nil {
+			t.Fatal("expected an error for a rejected password")
+		}
+		if got := client.GetConfig().Password; got != "" {
+			t.Errorf("expected config password to stay empty, got %q", got)
+		}
+		if _, password := client.Options().CredentialsProvider(); password != "" {
+			t.Errorf("expected new connections to keep the old password, got %q", password)
+		}
+
+		if err := client.RotatePassword(ctx, "new-secret"); err != nil {
+			t.Fatalf("RotatePassword: %v", err)
+		}
+		if got := client.GetConfig().Password; got != "new-secret" {
+			t.Errorf("expected config password %q, got %q", "new-secret", got)
+		}
+		if _, password := client.Options().CredentialsProvider(); password != "new-secret" {
+			t.Errorf("expected new connections to use the new password, got %q", password)
+		}
+	})
+}
+
+// fakeAuth answers AUTH commands itself, accepting only its password
+type fakeAuth string
+
+func (h fakeAuth) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h fakeAuth) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if cmd.Name() != "auth" {
+			return next(ctx, cmd)
+		}
+		args := cmd.Args()
+		if args[len(args)-1] != string(h) {
+			err := serverError("WRONGPASS invalid username-password pair or user is disabled.")
+			cmd.SetErr(err)
+			return err
+		}
+		cmd.(*redis.StatusCmd).SetVal("OK")
+		return nil
+	}
+}
+
+func (h fakeAuth) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+// TestRotatePasswordConcurrent tests that readers running alongside
+// RotatePassword see the old config or the new one, without data races
+// (run with -race)
+func TestRotatePasswordConcurrent(t *testing.T) {
+	newTestClient(t)
+	ctx := context.Background()
+
+	// The test server has no password, so keep every connection warm and
+	// never dial one with the rotated password
+	cfg := DefaultConfig()
+	cfg.PoolSize = 5
+	cfg.MinIdleConns = 5
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+	if err := client.Warmup(ctx); err != nil {
+		t.Fatalf("Warmup: %v", err)
+	}
+	key := "rediskit:test:rotatepassword:concurrent"
+	if err := client.SetJSON(ctx, key, "v", time.Minute); err != nil {
+		t.Fatalf("SetJSON: %v", err)
+	}
+	defer client.Del(ctx, key)
+	client.AddHook(fakeAuth("new-secret"))
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				var v string
+				if err := client.GetJSON(ctx, key, &v); err != nil {
+					t.Errorf("GetJSON: %v", err)
+					return
+				}
+				if password := client.GetConfig().Password; password != "" && password != "new-secret" {
+					t.Errorf("unexpected config password %q", password)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := client.RotatePassword(ctx, "new-secret"); err != nil {
+			t.Errorf("RotatePassword: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	close(stop)
+	wg.Wait()
+
+	if got := client.GetConfig().Password; got != "new-secret" {
+		t.Errorf("expected config password %q, got %q", "new-secret", got)
+	}
+}
@@ -0,0 +1,76 @@
+package rediskit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LastAcquireWait returns how long the most recent callers had to wait for
+// a free pool connection, averaged over the waits that finished since the
+// previous command. It separates pool contention from Redis latency when
+// investigating slow commands. It stays zero until a caller has to wait and
+// requires TrackPoolWait.
+func (c *Client) LastAcquireWait() time.Duration {
+	return time.Duration(c.poolWait.last.Load())
+}
+
+// poolWaitTracker derives per-acquisition wait times from the pool's
+// cumulative wait counters
+type poolWaitTracker struct {
+	count atomic.Uint32 // pool WaitCount at the last observation
+	last  atomic.Int64  // nanoseconds
+
+	mu  sync.Mutex
+	dur int64 // pool WaitDurationNs at the last observation
+}
+
+// observe records the waits that happened since the previous observation
+func (t *poolWaitTracker) observe(count uint32, durNs int64) {
+	if count == t.count.Load() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prev := t.count.Load()
+	if count <= prev {
+		return
+	}
+	t.last.Store((durNs - t.dur) / int64(count-prev))
+	t.dur = durNs
+	t.count.Store(count)
+}
+
+// poolWaitHook samples pool wait counters after each command
+type poolWaitHook struct {
+	rdb     *redis.Client
+	tracker *poolWaitTracker
+}
+
+func (h poolWaitHook) sample() {
+	s := h.rdb.PoolStats()
+	h.tracker.observe(s.WaitCount, s.WaitDurationNs)
+}
+
+func (h poolWaitHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h poolWaitHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		h.sample()
+		return err
+	}
+}
+
+func (h poolWaitHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		h.sample()
+		return err
+	}
+}
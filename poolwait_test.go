@@ -0,0 +1,73 @@
+package rediskit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestPoolWaitTracker tests deriving wait times from cumulative counters
+func TestPoolWaitTracker(t *testing.T) {
+	var tr poolWaitTracker
+
+	tr.observe(0, 0)
+	if got := tr.last.Load(); got != 0 {
+		t.Errorf("expected no wait before any waits, got %d", got)
+	}
+
+	tr.observe(2, int64(40*time.Millisecond))
+	if got := time.Duration(tr.last.Load()); got != 20*time.Millisecond {
+		t.Errorf("expected 20ms average, got %v", got)
+	}
+
+	// No new waits leaves the last value in place
+	tr.observe(2, int64(40*time.Millisecond))
+	if got := time.Duration(tr.last.Load()); got != 20*time.Millisecond {
+		t.Errorf("expected 20ms to be kept, got %v", got)
+	}
+
+	tr.observe(3, int64(45*time.Millisecond))
+	if got := time.Duration(tr.last.Load()); got != 5*time.Millisecond {
+		t.Errorf("expected 5ms, got %v", got)
+	}
+
+	// Out-of-order observations are ignored
+	tr.observe(1, int64(time.Second))
+	if got := time.Duration(tr.last.Load()); got != 5*time.Millisecond {
+		t.Errorf("expected 5ms to be kept, got %v", got)
+	}
+}
+
+// TestLastAcquireWait tests measuring contention for pool connections
+func TestLastAcquireWait(t *testing.T) {
+	newTestClient(t) // skip without Redis
+
+	cfg := DefaultConfig()
+	cfg.PoolSize = 1
+	cfg.MinIdleConns = 0
+	cfg.TrackPoolWait = true
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	if got := client.LastAcquireWait(); got != 0 {
+		t.Errorf("expected zero before contention, got %v", got)
+	}
+
+	// Hold the only connection so the next command has to wait for it
+	cn := client.Conn()
+	if err := cn.Ping(ctx).Err(); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	time.AfterFunc(50*time.Millisecond, func() { cn.Close() })
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	if got := client.LastAcquireWait(); got < 40*time.Millisecond {
+		t.Errorf("expected a wait of about 50ms, got %v", got)
+	}
+}
@@ -0,0 +1,110 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Message is a message received on a subscribed channel
+type Message struct {
+	Channel    string
+	Payload    string
+	ReceivedAt time.Time
+}
+
+// SubscribeWithReconnect subscribes to channels and delivers their messages
+// on the returned channel. When the subscription's connection fails, it
+// resubscribes with the client's retry backoff until it succeeds; messages
+// published while disconnected are lost, as with any Pub/Sub subscriber.
+// The returned channel is closed once ctx is cancelled or the client shuts
+// down. The first subscription is made before returning, so its failure is
+// reported directly.
+func (c *Client) SubscribeWithReconnect(ctx context.Context, channels ...string) (<-chan Message, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("%w: at least one channel is required", ErrInvalidArgument)
+	}
+
+	ps, err := c.subscribe(ctx, channels)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan Message)
+	ctx, cancel := c.backgroundContext(ctx)
+	go func() {
+		defer cancel()
+		defer close(out)
+		logger := st.config.Logger
+		for attempt := 0; ; attempt++ {
+			if ps != nil {
+				err := c.receiveMessages(ctx, ps, out)
+				ps.Close()
+				attempt = 0
+				if ctx.Err() != nil {
+					return
+				}
+				if logger != nil {
+					logger.Warnf("subscription to %v lost, resubscribing: %v", channels, err)
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			timer := time.NewTimer(c.nextRetry("SubscribeWithReconnect", attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			var err error
+			if ps, err = c.subscribe(ctx, channels); logger != nil {
+				if err != nil {
+					logger.Debugf("resubscribing to %v failed: %v", channels, err)
+				} else {
+					logger.Infof("resubscribed to %v", channels)
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// subscribe subscribes to channels and waits for the server to confirm
+func (c *Client) subscribe(ctx context.Context, channels []string) (*redis.PubSub, error) {
+	st := c.load()
+	ps := st.rdb.Subscribe(ctx, channels...)
+	if _, err := ps.Receive(ctx); err != nil {
+		ps.Close()
+		return nil, err
+	}
+	return ps, nil
+}
+
+// receiveMessages forwards messages from ps to out until receiving fails
+// or ctx is done, returning the receive error
+func (c *Client) receiveMessages(ctx context.Context, ps *redis.PubSub, out chan<- Message) error {
+	// A blocked receive does not watch ctx, so closing ps unblocks it
+	stop := context.AfterFunc(ctx, func() { ps.Close() })
+	defer stop()
+
+	for {
+		msg, err := ps.ReceiveMessage(ctx)
+		if err != nil {
+			return err
+		}
+		select {
+		case out <- Message{Channel: msg.Channel, Payload: msg.Payload, ReceivedAt: time.Now()}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
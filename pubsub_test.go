@@ -0,0 +1,151 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// dropProxy forwards TCP connections to a Redis server and can sever all of
+// them at once to simulate a dropped connection
+type dropProxy struct {
+	ln     net.Listener
+	target string
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func newDropProxy(t *testing.T, target string) *dropProxy {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	p := &dropProxy{ln: ln, target: target}
+	t.Cleanup(func() {
+		ln.Close()
+		p.drop()
+	})
+	go p.serve()
+	return p
+}
+
+func (p *dropProxy) serve() {
+	for {
+		client, err := p.ln.Accept()
+		if err != nil {
+			return
+		}
+		server, err := net.Dial("tcp", p.target)
+		if err != nil {
+			client.Close()
+			continue
+		}
+		p.mu.Lock()
+		p.conns = append(p.conns, client, server)
+		p.mu.Unlock()
+		go func() { io.Copy(server, client); server.Close() }()
+		go func() { io.Copy(client, server); client.Close() }()
+	}
+}
+
+func (p *dropProxy) drop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, conn := range p.conns {
+		conn.Close()
+	}
+	p.conns = nil
+}
+
+func (p *dropProxy) port() string {
+	return strconv.Itoa(p.ln.Addr().(*net.TCPAddr).Port)
+}
+
+// TestSubscribeWithReconnect tests that subscriptions survive dropped connections
+func TestSubscribeWithReconnect(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.SubscribeWithReconnect(context.Background(), "ch"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("no channels", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.SubscribeWithReconnect(context.Background()); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	publisher := newTestClient(t)
+	cfg := publisher.GetConfig()
+	proxy := newDropProxy(t, net.JoinHostPort(cfg.Host, cfg.Port))
+
+	subCfg := DefaultConfig()
+	subCfg.Port = proxy.port()
+	subscriber, err := NewClient(subCfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer subscriber.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	channel := "rediskit:test:pubsub"
+	messages, err := subscriber.SubscribeWithReconnect(ctx, channel)
+	if err != nil {
+		t.Fatalf("SubscribeWithReconnect: %v", err)
+	}
+
+	// Publish until the payload arrives, since messages published while the
+	// subscriber is reconnecting are lost
+	expect := func(payload string) {
+		t.Helper()
+		deadline := time.After(5 * time.Second)
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			publisher.Publish(ctx, channel, payload)
+			select {
+			case msg, ok := <-messages:
+				if !ok {
+					t.Fatal("message channel closed unexpectedly")
+				}
+				if msg.Channel != channel || msg.ReceivedAt.IsZero() {
+					t.Fatalf("unexpected message %+v", msg)
+				}
+				if msg.Payload == payload {
+					return
+				}
+			case <-deadline:
+				t.Fatalf("timed out waiting for %q", payload)
+			case <-ticker.C:
+			}
+		}
+	}
+
+	expect("before drop")
+	proxy.drop()
+	expect("after drop")
+
+	cancel()
+	select {
+	case _, ok := <-messages:
+		for ok {
+			_, ok = <-messages
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message channel not closed after cancel")
+	}
+}
@@ -0,0 +1,75 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// popJobPollInterval bounds each blocking BRPOP so PopJob notices a
+// cancelled context within about this long
+const popJobPollInterval = time.Second
+
+// PushJob appends payload, encoded as SetJSON encodes values, to the job
+// queue stored as the list queue
+func (c *Client) PushJob(ctx context.Context, queue string, payload any) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	data, err := c.encodeJSON(queue, payload)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	return st.rdb.LPush(ctx, queue, data).Err()
+}
+
+// PopJob removes the oldest job from queue and decodes it into dest,
+// blocking until one arrives, timeout passes, or ctx is done. It returns
+// ErrQueueEmpty on timeout and the context error on cancellation. A zero
+// timeout waits for ctx alone. Timeouts have a resolution of one second,
+// the unit of BRPOP.
+func (c *Client) PopJob(ctx context.Context, queue string, timeout time.Duration, dest any) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if timeout < 0 {
+		return fmt.Errorf("%w: timeout must not be negative", ErrInvalidArgument)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		wait := popJobPollInterval
+		if timeout > 0 {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return ErrQueueEmpty
+			}
+			// Round up to whole seconds, which is all BRPOP takes
+			wait = min(wait, (remaining + time.Second - 1).Truncate(time.Second))
+		}
+
+		popCtx, cancel := c.blockingContext(ctx, wait)
+		vals, err := st.rdb.BRPop(popCtx, wait, queue).Result()
+		cancel()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return err
+		}
+		return c.decodeJSON(queue, []byte(vals[1]), dest)
+	}
+}
@@ -0,0 +1,100 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestJobQueue tests pushing and popping JSON jobs
+func TestJobQueue(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.PushJob(context.Background(), "q", 1); err != ErrNilClient {
+			t.Errorf("PushJob: expected ErrNilClient, got %v", err)
+		}
+		var v int
+		if err := client.PopJob(context.Background(), "q", time.Second, &v); err != ErrNilClient {
+			t.Errorf("PopJob: expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("negative timeout", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		var v int
+		if err := client.PopJob(context.Background(), "q", -time.Second, &v); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	queue := "rediskit:test:queue"
+	client.Del(ctx, queue)
+	defer client.Del(ctx, queue)
+
+	type job struct {
+		ID   int    `json:"id"`
+		Kind string `json:"kind"`
+	}
+
+	t.Run("round trip in order", func(t *testing.T) {
+		jobs := []job{{ID: 1, Kind: "email"}, {ID: 2, Kind: "resize"}}
+		for _, j := range jobs {
+			if err := client.PushJob(ctx, queue, j); err != nil {
+				t.Fatalf("PushJob: %v", err)
+			}
+		}
+		for _, want := range jobs {
+			var got job
+			if err := client.PopJob(ctx, queue, time.Second, &got); err != nil {
+				t.Fatalf("PopJob: %v", err)
+			}
+			if got != want {
+				t.Errorf("expected %+v, got %+v", want, got)
+			}
+		}
+	})
+
+	t.Run("empty queue times out", func(t *testing.T) {
+		start := time.Now()
+		var got job
+		err := client.PopJob(ctx, queue, time.Second, &got)
+		if !errors.Is(err, ErrQueueEmpty) {
+			t.Errorf("expected ErrQueueEmpty, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < time.Second {
+			t.Errorf("expected to wait the full timeout, returned after %v", elapsed)
+		}
+	})
+
+	t.Run("cancellation stops the wait", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(50*time.Millisecond, cancel)
+		start := time.Now()
+		var got job
+		err := client.PopJob(ctx, queue, 0, &got)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > popJobPollInterval+time.Second {
+			t.Errorf("expected cancellation to be noticed promptly, took %v", elapsed)
+		}
+	})
+
+	t.Run("job pushed while waiting", func(t *testing.T) {
+		time.AfterFunc(50*time.Millisecond, func() { client.PushJob(ctx, queue, job{ID: 3}) })
+		var got job
+		if err := client.PopJob(ctx, queue, 5*time.Second, &got); err != nil {
+			t.Fatalf("PopJob: %v", err)
+		}
+		if got.ID != 3 {
+			t.Errorf("expected job 3, got %+v", got)
+		}
+	})
+}
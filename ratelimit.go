@@ -0,0 +1,71 @@
+package rediskit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// allowNScript implements a sliding-window log in the sorted set KEYS[1].
+// ARGV holds the current time and window in milliseconds, the limit, the
+// number of requested events, and a unique member prefix. It returns
+// {allowed, retryAfterMs}.
+var allowNScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+local n = tonumber(ARGV[4])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+if count + n <= limit then
+	for i = 1, n do
+		redis.call('ZADD', KEYS[1], now, ARGV[5] .. ':' .. i)
+	end
+	redis.call('PEXPIRE', KEYS[1], window)
+	return {1, 0}
+end
+
+-- Wait until enough of the oldest events leave the window
+local oldest = redis.call('ZRANGE', KEYS[1], count + n - limit - 1, count + n - limit - 1, 'WITHSCORES')
+return {0, tonumber(oldest[2]) + window - now}
+`)
+
+// AllowN reports whether n events may happen now under a limit of limit
+// events per sliding window, recording them if so. When denied, it also
+// returns how long to wait before the same request would be allowed.
+func (c *Client) AllowN(ctx context.Context, key string, limit int, window time.Duration, n int) (bool, time.Duration, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return false, 0, ErrNilClient
+	}
+	if limit <= 0 || window <= 0 || n <= 0 {
+		return false, 0, fmt.Errorf("%w: limit, window, and n must be greater than 0", ErrInvalidArgument)
+	}
+	if n > limit {
+		return false, 0, fmt.Errorf("%w: n %d exceeds limit %d", ErrInvalidArgument, n, limit)
+	}
+
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return false, 0, fmt.Errorf("generate event id: %w", err)
+	}
+	now := c.now(ctx).UnixMilli()
+
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	res, err := allowNScript.Run(ctx, st.rdb, []string{key},
+		now, window.Milliseconds(), limit, n, hex.EncodeToString(buf)).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	if len(res) != 2 {
+		return false, 0, errors.New("unexpected reply from rate limit script")
+	}
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
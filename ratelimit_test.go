@@ -0,0 +1,90 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestAllowN tests the sliding-window rate limiter
+func TestAllowN(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, _, err := client.AllowN(context.Background(), "k", 1, time.Second, 1); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		tests := []struct {
+			name     string
+			limit, n int
+			window   time.Duration
+		}{
+			{"zero limit", 0, 1, time.Second},
+			{"zero window", 1, 1, 0},
+			{"zero n", 1, 0, time.Second},
+			{"n above limit", 2, 3, time.Second},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, _, err := client.AllowN(context.Background(), "k", tt.limit, tt.window, tt.n)
+				if !errors.Is(err, ErrInvalidArgument) {
+					t.Errorf("expected ErrInvalidArgument, got %v", err)
+				}
+			})
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:ratelimit"
+	client.Del(ctx, key)
+	defer client.Del(ctx, key)
+
+	const limit = 5
+	window := 2 * time.Second
+
+	allowed, _, err := client.AllowN(ctx, key, limit, window, 3)
+	if err != nil || !allowed {
+		t.Fatalf("expected first batch of 3 to be allowed, got %v, %v", allowed, err)
+	}
+	allowed, _, err = client.AllowN(ctx, key, limit, window, 2)
+	if err != nil || !allowed {
+		t.Fatalf("expected batch of 2 to fill the limit, got %v, %v", allowed, err)
+	}
+
+	allowed, first, err := client.AllowN(ctx, key, limit, window, 1)
+	if err != nil {
+		t.Fatalf("AllowN: %v", err)
+	}
+	if allowed {
+		t.Fatal("expected request over the limit to be denied")
+	}
+	if first <= 0 || first > window {
+		t.Fatalf("expected retry-after within the window, got %v", first)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	allowed, second, err := client.AllowN(ctx, key, limit, window, 1)
+	if err != nil {
+		t.Fatalf("AllowN: %v", err)
+	}
+	if allowed {
+		t.Fatal("expected request to still be denied")
+	}
+	if second >= first {
+		t.Errorf("expected retry-after to decrease, got %v then %v", first, second)
+	}
+
+	if n := client.ZCard(ctx, key).Val(); n != limit {
+		t.Errorf("expected denied requests not to be recorded, got %d events", n)
+	}
+}
@@ -0,0 +1,87 @@
+package rediskit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// reconfigureGracePeriod is how long a replaced client stays open for the
+// calls still using it
+const reconfigureGracePeriod = 10 * time.Second
+
+// Reconfigure validates cfg and replaces the underlying go-redis client,
+// and those of any replicas, with new ones built from it, e.g. after a
+// password rotation. The old clients are closed after a grace period so
+// calls already running on them can finish. The helpers of Client are safe
+// to call concurrently with Reconfigure; methods of the embedded
+// redis.Client called directly are not. Pub/Sub subscriptions and Conn
+// values keep the old client until closed. An invalid cfg is returned as
+// an error and leaves the client unchanged. Clients created by
+// NewFailoverClient cannot be reconfigured, and once Close or Shutdown has
+// been called Reconfigure returns ErrClientClosed.
+func (c *Client) Reconfigure(cfg *Config) error {
+	if c.load().rdb == nil {
+		return ErrNilClient
+	}
+	if cfg == nil {
+		return fmt.Errorf("%w: config is required", ErrInvalidConfig)
+	}
+	if c.failover {
+		return fmt.Errorf("%w: failover clients cannot be reconfigured", ErrInvalidConfig)
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	creds := newCredentials(cfg)
+	opts := cfg.options()
+	creds.install(opts)
+	rdb := redis.NewClient(opts)
+	if err := cfg.instrumentTracing(rdb); err != nil {
+		rdb.Close()
+		return err
+	}
+	c.installHooks(rdb, cfg)
+	replicas, err := c.newReplicas(cfg, creds)
+	if err != nil {
+		rdb.Close()
+		return err
+	}
+
+	c.reconfigure.Lock()
+	defer c.reconfigure.Unlock()
+
+	if c.closed {
+		rdb.Close()
+		closeReplicas(replicas)
+		return ErrClientClosed
+	}
+	for _, h := range c.hooks {
+		rdb.AddHook(h)
+		for _, replica := range replicas {
+			replica.AddHook(h)
+		}
+	}
+	old := c.load()
+	c.state.Store(&clientState{rdb: rdb, config: cfg, replicas: replicas, creds: creds})
+	c.Client = rdb
+
+	if c.unregister != nil {
+		c.unregister()
+		c.unregister = nil
+	}
+	if cfg.WarnDuplicateClients {
+		registerClient(c, cfg.target())
+	}
+
+	if cfg.Logger != nil {
+		cfg.Logger.Infof("reconfigured client for %s; closing the old one in %s", cfg.target(), reconfigureGracePeriod)
+	}
+	time.AfterFunc(reconfigureGracePeriod, func() {
+		old.rdb.Close()
+		closeReplicas(old.replicas)
+	})
+	return nil
+}
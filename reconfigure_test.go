@@ -0,0 +1,175 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestReconfigure tests replacing the underlying client at runtime
+func TestReconfigure(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.Reconfigure(DefaultConfig()); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid config leaves client unchanged", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		before, rdb := client.GetConfig(), client.Client
+
+		invalid := DefaultConfig()
+		invalid.PoolSize = 0
+		for _, cfg := range []*Config{nil, invalid} {
+			if err := client.Reconfigure(cfg); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("expected ErrInvalidConfig, got %v", err)
+			}
+		}
+		if client.GetConfig() != before || client.Client != rdb {
+			t.Error("expected the client to be unchanged")
+		}
+	})
+
+	t.Run("failover clients are rejected", func(t *testing.T) {
+		client, err := NewFailoverClient(testFailoverConfig())
+		if err != nil {
+			t.Fatalf("NewFailoverClient: %v", err)
+		}
+		defer client.Close()
+		if err := client.Reconfigure(DefaultConfig()); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	t.Run("closed clients are rejected", func(t *testing.T) {
+		for _, stop := range []struct {
+			name string
+			fn   func(*Client) error
+		}{
+			{"Close", (*Client).Close},
+			{"Shutdown", func(c *Client) error { return c.Shutdown(context.Background()) }},
+		} {
+			t.Run(stop.name, func(t *testing.T) {
+				cfg := DefaultConfig()
+				cfg.WarnDuplicateClients = true
+				cfg.Logger = &recordingLogger{}
+				client, err := NewClient(cfg)
+				if err != nil {
+					t.Fatalf("NewClient: %v", err)
+				}
+				if err := stop.fn(client); err != nil {
+					t.Fatalf("%s: %v", stop.name, err)
+				}
+				if err := client.Reconfigure(cfg); !errors.Is(err, ErrClientClosed) {
+					t.Errorf("expected ErrClientClosed, got %v", err)
+				}
+				if n := liveClients(cfg.target()); n != 0 {
+					t.Errorf("expected the registration to stay released, got %d live clients", n)
+				}
+			})
+		}
+	})
+
+	t.Run("close races with reconfigure", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.WarnDuplicateClients = true
+		cfg.Logger = &recordingLogger{}
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Reconfigure(cfg); err != nil && !errors.Is(err, ErrClientClosed) {
+				t.Errorf("Reconfigure: %v", err)
+			}
+		}()
+		client.Close()
+		wg.Wait()
+		if n := liveClients(cfg.target()); n != 0 {
+			t.Errorf("expected no live clients after close, got %d", n)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:reconfigure"
+	old := client.Client
+
+	cfg := DefaultConfig()
+	cfg.DB = 1
+	if err := client.Reconfigure(cfg); err != nil {
+		t.Fatalf("Reconfigure: %v", err)
+	}
+	if client.GetConfig() != cfg {
+		t.Error("expected GetConfig to return the new config")
+	}
+	if client.Client == old {
+		t.Fatal("expected the underlying client to be replaced")
+	}
+
+	if err := client.Set(ctx, key, "db1", 0).Err(); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	defer client.Del(ctx, key)
+	if n := old.Exists(ctx, key).Val(); n != 0 {
+		t.Error("expected the write to go to the new DB")
+	}
+	if err := old.Ping(ctx).Err(); err != nil {
+		t.Errorf("expected the old client to stay usable during the grace period, got %v", err)
+	}
+}
+
+// TestReconfigureConcurrent tests that helpers running alongside
+// Reconfigure see either the old state or the new one, without data races
+// (run with -race)
+func TestReconfigureConcurrent(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:reconfigure:concurrent"
+	if err := client.SetJSON(ctx, key, "v", time.Minute); err != nil {
+		t.Fatalf("SetJSON: %v", err)
+	}
+	defer client.Del(ctx, key)
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				var v string
+				if err := client.GetJSON(ctx, key, &v); err != nil {
+					t.Errorf("GetJSON: %v", err)
+					return
+				}
+				_ = client.GetConfig().PoolSize
+				client.ReadOnly()
+			}
+		}()
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := client.Reconfigure(DefaultConfig()); err != nil {
+			t.Errorf("Reconfigure: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	close(stop)
+	wg.Wait()
+}
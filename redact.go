@@ -0,0 +1,79 @@
+package rediskit
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// redactedMask replaces secret values in Config output
+const redactedMask = "****"
+
+// String renders the config with secrets masked: Password and
+// EncryptionKey show as **** when set, and pointer, func, and interface
+// fields such as TLSConfig only show whether they are set
+func (c *Config) String() string {
+	return c.render(false)
+}
+
+// GoString renders the config like String for the %#v verb
+func (c *Config) GoString() string {
+	return c.render(true)
+}
+
+// Redacted returns a copy of the config with Password and EncryptionKey
+// blanked, safe to pass to structured loggers. Maps and slices are shared
+// with the original.
+func (c *Config) Redacted() *Config {
+	if c == nil {
+		return nil
+	}
+	redacted := *c
+	redacted.Password = ""
+	redacted.EncryptionKey = nil
+	return &redacted
+}
+
+// render formats each field of the config, in Go syntax if goSyntax is set
+func (c *Config) render(goSyntax bool) string {
+	if c == nil {
+		if goSyntax {
+			return "(*rediskit.Config)(nil)"
+		}
+		return "<nil>"
+	}
+
+	verb, sep := "%v", " "
+	if goSyntax {
+		verb, sep = "%#v", ", "
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	fields := make([]string, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		f, name := v.Field(i), v.Type().Field(i).Name
+		var value string
+		switch {
+		case name == "Password" || name == "EncryptionKey":
+			if f.Len() > 0 {
+				value = redactedMask
+			}
+			if goSyntax {
+				value = fmt.Sprintf("%q", value)
+			}
+		case f.Kind() == reflect.Pointer || f.Kind() == reflect.Func || f.Kind() == reflect.Interface:
+			value = "<nil>"
+			if !f.IsNil() {
+				value = "<set>"
+			}
+		default:
+			value = fmt.Sprintf(verb, f.Interface())
+		}
+		fields = append(fields, name+":"+value)
+	}
+
+	if goSyntax {
+		return "&rediskit.Config{" + strings.Join(fields, sep) + "}"
+	}
+	return "{" + strings.Join(fields, sep) + "}"
+}
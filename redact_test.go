@@ -0,0 +1,85 @@
+package rediskit
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestConfigString tests that secrets never appear in rendered configs
+func TestConfigString(t *testing.T) {
+	secret := "hunter2-secret"
+	key := []byte("0123456789abcdef")
+	populated := func() *Config {
+		cfg := DefaultConfig()
+		cfg.Password = secret
+		cfg.EncryptionKey = key
+		cfg.TLSConfig = &tls.Config{ServerName: "redis.internal"}
+		cfg.KeyPrefix = "app:"
+		return cfg
+	}
+
+	tests := []struct {
+		name   string
+		render func(*Config) string
+	}{
+		{name: "String", render: (*Config).String},
+		{name: "GoString", render: (*Config).GoString},
+		{name: "%v", render: func(c *Config) string { return fmt.Sprintf("%v", c) }},
+		{name: "%+v", render: func(c *Config) string { return fmt.Sprintf("%+v", c) }},
+		{name: "%#v", render: func(c *Config) string { return fmt.Sprintf("%#v", c) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := tt.render(populated())
+			for _, leaked := range []string{secret, string(key), "redis.internal"} {
+				if strings.Contains(out, leaked) {
+					t.Errorf("output contains secret %q: %s", leaked, out)
+				}
+			}
+			for _, want := range []string{"Password:", redactedMask, "Host:", "localhost", "KeyPrefix:", "app:", "TLSConfig:<set>"} {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected output to contain %q: %s", want, out)
+				}
+			}
+
+			empty := DefaultConfig()
+			if out := tt.render(empty); strings.Contains(out, redactedMask) {
+				t.Errorf("expected no mask for an empty password: %s", out)
+			}
+		})
+	}
+
+	t.Run("nil config", func(t *testing.T) {
+		var cfg *Config
+		if got := cfg.String(); got != "<nil>" {
+			t.Errorf("expected <nil>, got %q", got)
+		}
+	})
+}
+
+// TestConfigRedacted tests copying a config with secrets blanked
+func TestConfigRedacted(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Password = "secret"
+	cfg.EncryptionKey = []byte("0123456789abcdef")
+
+	redacted := cfg.Redacted()
+	if redacted == cfg {
+		t.Fatal("expected a copy")
+	}
+	if redacted.Password != "" || redacted.EncryptionKey != nil {
+		t.Errorf("expected secrets to be blanked, got %q and %v", redacted.Password, redacted.EncryptionKey)
+	}
+	if redacted.Host != cfg.Host || redacted.PoolSize != cfg.PoolSize {
+		t.Error("expected other fields to be copied")
+	}
+	if cfg.Password != "secret" || cfg.EncryptionKey == nil {
+		t.Error("expected the original to be unchanged")
+	}
+	if (*Config)(nil).Redacted() != nil {
+		t.Error("expected nil for a nil config")
+	}
+}
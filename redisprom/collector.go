@@ -0,0 +1,202 @@
+// Package redisprom exports rediskit client metrics to Prometheus. It lives
+// in its own package so that only programs using it depend on the
+// Prometheus client library.
+package redisprom
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/alinemone/go-redis-kit"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+const namespace = "redis"
+
+// Collector is a prometheus.Collector for a rediskit client. It counts
+// commands and errors and times them through a hook on the client, and
+// reads pool statistics, the hit ratio, circuit states, and retry counts
+// live on every scrape.
+type Collector struct {
+	client *rediskit.Client
+
+	commands    *prometheus.CounterVec
+	errors      *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	healthCheck prometheus.Histogram
+
+	poolHits     *prometheus.Desc
+	poolMisses   *prometheus.Desc
+	poolTimeouts *prometheus.Desc
+	poolTotal    *prometheus.Desc
+	poolIdle     *prometheus.Desc
+	hitRatio     *prometheus.Desc
+	circuit      *prometheus.Desc
+	retries      *prometheus.Desc
+	backoff      *prometheus.Desc
+}
+
+// NewCollector creates a collector for client and installs the hook that
+// feeds its command metrics, so only commands issued afterwards are
+// counted. Create one collector per client.
+func NewCollector(client *rediskit.Client) (*Collector, error) {
+	if client == nil || client.Client == nil {
+		return nil, rediskit.ErrNilClient
+	}
+
+	c := &Collector{
+		client: client,
+		commands: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "commands_total",
+			Help:      "Commands sent, by command name.",
+		}, []string{"command"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "command_errors_total",
+			Help:      "Failed commands, by error type.",
+		}, []string{"type"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "command_duration_seconds",
+			Help:      "Command latency, by command name; pipelines are timed as a whole.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"command"}),
+		healthCheck: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "health_check_duration_seconds",
+			Help:      "Latency of PING commands, as sent by HealthCheck.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}),
+
+		poolHits:     prometheus.NewDesc(namespace+"_pool_hits_total", "Times a free connection was found in the pool.", nil, nil),
+		poolMisses:   prometheus.NewDesc(namespace+"_pool_misses_total", "Times a free connection was not found in the pool.", nil, nil),
+		poolTimeouts: prometheus.NewDesc(namespace+"_pool_timeouts_total", "Times a wait for a connection timed out.", nil, nil),
+		poolTotal:    prometheus.NewDesc(namespace+"_pool_conns", "Connections in the pool.", nil, nil),
+		poolIdle:     prometheus.NewDesc(namespace+"_pool_idle_conns", "Idle connections in the pool.", nil, nil),
+		hitRatio:     prometheus.NewDesc(namespace+"_keyspace_hit_ratio", "Keyspace hit ratio from the most recent SampleHitRatio call.", nil, nil),
+		circuit:      prometheus.NewDesc(namespace+"_circuit_state", "Circuit breaker state by scope: 0 closed, 1 open, 2 half-open.", []string{"scope"}, nil),
+		retries:      prometheus.NewDesc(namespace+"_retries_total", "Retries made by rediskit helpers, by operation.", []string{"operation"}, nil),
+		backoff:      prometheus.NewDesc(namespace+"_backoff_seconds_total", "Time spent in backoff before retries, by operation.", []string{"operation"}, nil),
+	}
+	client.AddHook(metricsHook{collector: c})
+	return c, nil
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.commands.Describe(ch)
+	c.errors.Describe(ch)
+	c.duration.Describe(ch)
+	c.healthCheck.Describe(ch)
+	ch <- c.poolHits
+	ch <- c.poolMisses
+	ch <- c.poolTimeouts
+	ch <- c.poolTotal
+	ch <- c.poolIdle
+	ch <- c.hitRatio
+	ch <- c.circuit
+	ch <- c.retries
+	ch <- c.backoff
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.commands.Collect(ch)
+	c.errors.Collect(ch)
+	c.duration.Collect(ch)
+	c.healthCheck.Collect(ch)
+
+	stats := c.client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(c.poolHits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.poolMisses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.poolTimeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(c.poolTotal, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(c.poolIdle, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.hitRatio, prometheus.GaugeValue, c.client.HitRatio())
+	for scope, state := range c.client.CircuitStates() {
+		ch <- prometheus.MustNewConstMetric(c.circuit, prometheus.GaugeValue, float64(state), scope)
+	}
+	for op, stat := range c.client.RetryStats() {
+		ch <- prometheus.MustNewConstMetric(c.retries, prometheus.CounterValue, float64(stat.Retries), op)
+		ch <- prometheus.MustNewConstMetric(c.backoff, prometheus.CounterValue, stat.Backoff.Seconds(), op)
+	}
+}
+
+// observe records a command that finished with err
+func (c *Collector) observe(cmd redis.Cmder, err error) {
+	c.commands.WithLabelValues(cmd.Name()).Inc()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		c.errors.WithLabelValues(errorType(err)).Inc()
+	}
+}
+
+// errorType classifies err into a small set of label values. Server
+// errors are labelled by their error code, such as "WRONGTYPE".
+func errorType(err error) string {
+	var netErr net.Error
+	var redisErr redis.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, redis.ErrPoolTimeout):
+		return "pool_timeout"
+	case errors.Is(err, redis.ErrClosed):
+		return "closed"
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return "timeout"
+		}
+		return "network"
+	case errors.As(err, &redisErr):
+		code, _, _ := strings.Cut(redisErr.Error(), " ")
+		if code == "" || strings.ToUpper(code) != code {
+			return "ERR"
+		}
+		return code
+	}
+	return "other"
+}
+
+// metricsHook feeds a Collector from the client's commands
+type metricsHook struct {
+	collector *Collector
+}
+
+func (h metricsHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h metricsHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		elapsed := time.Since(start).Seconds()
+
+		h.collector.observe(cmd, err)
+		h.collector.duration.WithLabelValues(cmd.Name()).Observe(elapsed)
+		if cmd.Name() == "ping" {
+			h.collector.healthCheck.Observe(elapsed)
+		}
+		return err
+	}
+}
+
+func (h metricsHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		h.collector.duration.WithLabelValues("pipeline").Observe(time.Since(start).Seconds())
+
+		for _, cmd := range cmds {
+			h.collector.observe(cmd, cmd.Err())
+		}
+		return err
+	}
+}
@@ -0,0 +1,147 @@
+package redisprom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/alinemone/go-redis-kit"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/redis/go-redis/v9"
+)
+
+// serverError is a Redis server error reply
+type serverError string
+
+func (e serverError) Error() string { return string(e) }
+func (e serverError) RedisError()   {}
+
+// TestErrorType tests classifying command errors for the errors metric
+func TestErrorType(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "deadline", err: context.DeadlineExceeded, want: "timeout"},
+		{name: "canceled", err: fmt.Errorf("wrapped: %w", context.Canceled), want: "canceled"},
+		{name: "pool timeout", err: redis.ErrPoolTimeout, want: "pool_timeout"},
+		{name: "closed", err: redis.ErrClosed, want: "closed"},
+		{name: "network", err: &net.OpError{Op: "read", Err: errors.New("connection reset")}, want: "network"},
+		{name: "server code", err: serverError("WRONGTYPE Operation against a key holding the wrong kind of value"), want: "WRONGTYPE"},
+		{name: "server without code", err: serverError("something went wrong"), want: "ERR"},
+		{name: "other", err: errors.New("boom"), want: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorType(tt.err); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestCollector tests scraping a collector
+func TestCollector(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		if _, err := NewCollector(nil); err != rediskit.ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+		if _, err := NewCollector(&rediskit.Client{}); err != rediskit.ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	cfg := rediskit.DefaultConfig()
+	cfg.CircuitThreshold = 3
+	client, err := rediskit.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	collector, err := NewCollector(client)
+	if err != nil {
+		t.Fatalf("NewCollector: %v", err)
+	}
+	registry := prometheus.NewPedanticRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	if err := client.HealthCheck(); err != nil {
+		t.Skip("Redis not available for testing")
+	}
+	ctx := context.Background()
+	key := "rediskit:test:redisprom"
+	defer client.Del(ctx, key)
+	client.Set(ctx, key, "v", 0)
+	client.HGet(ctx, key, "field") // WRONGTYPE
+	retried := false
+	client.WithRetry(ctx, func(context.Context) error {
+		if !retried {
+			retried = true
+			return redis.TxFailedErr
+		}
+		return nil
+	})
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, f := range families {
+		got[f.GetName()] = true
+	}
+	for _, name := range []string{
+		"redis_commands_total",
+		"redis_command_errors_total",
+		"redis_command_duration_seconds",
+		"redis_health_check_duration_seconds",
+		"redis_pool_hits_total",
+		"redis_pool_misses_total",
+		"redis_pool_timeouts_total",
+		"redis_pool_conns",
+		"redis_pool_idle_conns",
+		"redis_keyspace_hit_ratio",
+		"redis_circuit_state",
+		"redis_retries_total",
+		"redis_backoff_seconds_total",
+	} {
+		if !got[name] {
+			t.Errorf("expected metric %s to be exported", name)
+		}
+	}
+
+	if n := counterValue(t, families, "redis_command_errors_total", "WRONGTYPE"); n != 1 {
+		t.Errorf("expected 1 WRONGTYPE error, got %v", n)
+	}
+	if n := counterValue(t, families, "redis_commands_total", "set"); n != 1 {
+		t.Errorf("expected 1 set command, got %v", n)
+	}
+	if n := counterValue(t, families, "redis_retries_total", "WithRetry"); n != 1 {
+		t.Errorf("expected 1 retry, got %v", n)
+	}
+}
+
+// counterValue returns the value of the counter in families named name
+// whose only label has the given value
+func counterValue(t *testing.T, families []*dto.MetricFamily, name, label string) float64 {
+	t.Helper()
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			if m.GetLabel()[0].GetValue() == label {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
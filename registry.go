@@ -0,0 +1,49 @@
+package rediskit
+
+import "sync"
+
+// clientRegistry counts live clients per address and DB for duplicate
+// detection. It holds no references to clients, so it never keeps one alive.
+var clientRegistry = struct {
+	sync.Mutex
+	live map[string]int
+}{live: make(map[string]int)}
+
+// registerTarget records a client for target in the registry, warning on
+// logger, or the standard logger if nil, when another live client already
+// targets the same server and DB. It returns a function that releases the
+// count; calling it more than once is a no-op.
+func registerTarget(target string, logger Logger) func() {
+	clientRegistry.Lock()
+	clientRegistry.live[target]++
+	n := clientRegistry.live[target]
+	clientRegistry.Unlock()
+
+	if n > 1 {
+		if logger == nil {
+			logger = StdLogger{}
+		}
+		logger.Warnf("%d live clients for %s; consider reusing a single client", n, target)
+	}
+
+	return sync.OnceFunc(func() {
+		clientRegistry.Lock()
+		defer clientRegistry.Unlock()
+		if clientRegistry.live[target]--; clientRegistry.live[target] <= 0 {
+			delete(clientRegistry.live, target)
+		}
+	})
+}
+
+// registerClient registers c under target. The count is released by Close
+// or Shutdown; a client dropped without either stays counted.
+func registerClient(c *Client, target string) {
+	c.unregister = registerTarget(target, c.load().config.Logger)
+}
+
+// liveClients returns the number of registered clients for a target
+func liveClients(target string) int {
+	clientRegistry.Lock()
+	defer clientRegistry.Unlock()
+	return clientRegistry.live[target]
+}
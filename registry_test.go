@@ -0,0 +1,76 @@
+package rediskit
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDuplicateClientWarning tests detection of duplicate clients
+func TestDuplicateClientWarning(t *testing.T) {
+	var buf bytes.Buffer
+	orig := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(orig)
+
+	newCfg := func(warn bool) *Config {
+		return &Config{
+			Host:                 "dup-host",
+			Port:                 "6379",
+			DB:                   3,
+			PoolSize:             10,
+			DefaultTimeout:       5 * time.Second,
+			WarnDuplicateClients: warn,
+		}
+	}
+	target := "dup-host:6379/3"
+
+	first, err := NewClient(newCfg(true))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unexpected warning for first client: %s", buf.String())
+	}
+
+	second, err := NewClient(newCfg(true))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if !strings.Contains(buf.String(), target) {
+		t.Errorf("expected warning naming %s, got %q", target, buf.String())
+	}
+	if n := liveClients(target); n != 2 {
+		t.Errorf("expected 2 live clients, got %d", n)
+	}
+
+	// Clients that did not opt in are not tracked
+	untracked, err := NewClient(newCfg(false))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	untracked.Close()
+	if n := liveClients(target); n != 2 {
+		t.Errorf("expected 2 live clients, got %d", n)
+	}
+
+	first.Close()
+	second.Shutdown(context.Background())
+	second.Close() // closing twice must not release twice
+	if n := liveClients(target); n != 0 {
+		t.Errorf("expected no live clients after close, got %d", n)
+	}
+
+	buf.Reset()
+	third, err := NewClient(newCfg(true))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer third.Close()
+	if buf.Len() != 0 {
+		t.Errorf("unexpected warning after earlier clients closed: %s", buf.String())
+	}
+}
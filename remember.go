@@ -0,0 +1,74 @@
+package rediskit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// rememberLockTTL bounds how long a crashed Remember caller can hold up
+// others computing the same key
+const rememberLockTTL = 10 * time.Second
+
+// Remember decodes the JSON value cached at key into dest. On a miss it
+// calls fn, stores the result with ttl, and decodes it into dest. A lock
+// on key+":lock" makes concurrent misses across processes call fn once;
+// the others wait for the cached value, retrying with the client's backoff.
+// Errors from fn are wrapped in ErrComputeFailed; any other error comes
+// from the cache. If storing a computed value fails, dest is still filled.
+func (c *Client) Remember(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context) (any, error), dest any) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if fn == nil {
+		return fmt.Errorf("%w: fn is required", ErrInvalidArgument)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := c.GetJSON(ctx, key, dest)
+		if !errors.Is(err, ErrCacheMiss) {
+			return err
+		}
+
+		lock, err := c.Lock(ctx, key+":lock", rememberLockTTL)
+		if err == nil {
+			defer lock.Unlock(context.WithoutCancel(ctx))
+			return c.remember(ctx, key, ttl, fn, dest)
+		}
+		if !errors.Is(err, ErrLockNotAcquired) {
+			return err
+		}
+
+		timer := time.NewTimer(c.nextRetry("Remember", attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// remember computes and stores the value for key while holding its lock
+func (c *Client) remember(ctx context.Context, key string, ttl time.Duration, fn func(ctx context.Context) (any, error), dest any) error {
+	// Another caller may have stored the value before we took the lock
+	if err := c.GetJSON(ctx, key, dest); !errors.Is(err, ErrCacheMiss) {
+		return err
+	}
+
+	value, err := fn(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %q: %w", ErrComputeFailed, key, err)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encode %q: %w", key, err)
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("decode %q: %w", key, err)
+	}
+	return c.SetJSON(ctx, key, value, ttl)
+}
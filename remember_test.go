@@ -0,0 +1,136 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRemember tests the cache-aside helper
+func TestRemember(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		var v int
+		fn := func(context.Context) (any, error) { return 1, nil }
+		if err := client.Remember(context.Background(), "k", time.Minute, fn, &v); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("nil fn", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		var v int
+		if err := client.Remember(context.Background(), "k", time.Minute, nil, &v); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:remember"
+
+	t.Run("miss computes and stores", func(t *testing.T) {
+		defer client.Del(ctx, key)
+		var got string
+		if err := client.Remember(ctx, key, time.Minute, func(context.Context) (any, error) {
+			return "computed", nil
+		}, &got); err != nil {
+			t.Fatalf("Remember: %v", err)
+		}
+		if got != "computed" {
+			t.Errorf("expected %q, got %q", "computed", got)
+		}
+		if ttl := client.TTL(ctx, key).Val(); ttl <= 0 {
+			t.Errorf("expected a TTL, got %v", ttl)
+		}
+		if n := client.Exists(ctx, key+":lock").Val(); n != 0 {
+			t.Error("expected the lock to be released")
+		}
+	})
+
+	t.Run("hit skips fn", func(t *testing.T) {
+		defer client.Del(ctx, key)
+		if err := client.SetJSON(ctx, key, "cached", time.Minute); err != nil {
+			t.Fatalf("SetJSON: %v", err)
+		}
+		var got string
+		if err := client.Remember(ctx, key, time.Minute, func(context.Context) (any, error) {
+			t.Error("fn called on a hit")
+			return "computed", nil
+		}, &got); err != nil {
+			t.Fatalf("Remember: %v", err)
+		}
+		if got != "cached" {
+			t.Errorf("expected %q, got %q", "cached", got)
+		}
+	})
+
+	t.Run("compute error", func(t *testing.T) {
+		defer client.Del(ctx, key)
+		boom := errors.New("boom")
+		var got string
+		err := client.Remember(ctx, key, time.Minute, func(context.Context) (any, error) {
+			return nil, boom
+		}, &got)
+		if !errors.Is(err, ErrComputeFailed) || !errors.Is(err, boom) {
+			t.Errorf("expected ErrComputeFailed wrapping the fn error, got %v", err)
+		}
+		if n := client.Exists(ctx, key, key+":lock").Val(); n != 0 {
+			t.Error("expected nothing cached and the lock released")
+		}
+	})
+
+	t.Run("cache error", func(t *testing.T) {
+		defer client.Del(ctx, key)
+		client.Set(ctx, key, "not json", time.Minute)
+		var got string
+		err := client.Remember(ctx, key, time.Minute, func(context.Context) (any, error) {
+			return "computed", nil
+		}, &got)
+		if err == nil || errors.Is(err, ErrComputeFailed) {
+			t.Errorf("expected a cache error, got %v", err)
+		}
+	})
+
+	t.Run("concurrent misses compute once", func(t *testing.T) {
+		defer client.Del(ctx, key)
+		var calls atomic.Int32
+		fn := func(context.Context) (any, error) {
+			calls.Add(1)
+			time.Sleep(50 * time.Millisecond)
+			return 42, nil
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var got int
+				if err := client.Remember(ctx, key, time.Minute, fn, &got); err != nil {
+					errs <- err
+					return
+				}
+				if got != 42 {
+					errs <- errors.New("unexpected value")
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Errorf("Remember: %v", err)
+		}
+		if n := calls.Load(); n != 1 {
+			t.Errorf("expected fn to be called once, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package rediskit
+
+import (
+	"github.com/redis/go-redis/v9"
+)
+
+// ReadOnly returns a client for read commands. Each call picks the next of
+// ReplicaAddrs in turn; with no replicas configured it returns the primary,
+// so callers need not special-case it. Replicas reject writes, and may lag
+// behind the primary.
+func (c *Client) ReadOnly() redis.Cmdable {
+	st := c.load()
+	replicas := st.replicas
+	if len(replicas) == 0 {
+		return st.rdb
+	}
+	i := c.replicaNext.Add(1) - 1
+	return replicas[i%uint32(len(replicas))]
+}
+
+// newReplicas creates a client for each of cfg.ReplicaAddrs, sharing cfg's
+// settings, credentials and the hooks of c
+func (c *Client) newReplicas(cfg *Config, creds *credentials) ([]*redis.Client, error) {
+	replicas := make([]*redis.Client, 0, len(cfg.ReplicaAddrs))
+	for _, addr := range cfg.ReplicaAddrs {
+		opts := cfg.options()
+		opts.Addr = addr
+		creds.install(opts)
+		rdb := redis.NewClient(opts)
+		if err := cfg.instrumentTracing(rdb); err != nil {
+			rdb.Close()
+			closeReplicas(replicas)
+			return nil, err
+		}
+		c.installHooks(rdb, cfg)
+		replicas = append(replicas, rdb)
+	}
+	return replicas, nil
+}
+
+// closeReplicas closes replica clients, returning the first error
+func closeReplicas(replicas []*redis.Client) error {
+	var first error
+	for _, rdb := range replicas {
+		if err := rdb.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,61 @@
+package rediskit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestReadOnly tests routing reads to replicas
+func TestReadOnly(t *testing.T) {
+	t.Run("no replicas falls back to primary", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		for i := 0; i < 3; i++ {
+			if got := client.ReadOnly(); got != client.Client {
+				t.Fatalf("call %d: expected the primary, got %v", i, got)
+			}
+		}
+	})
+
+	t.Run("round robin across replicas", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ReplicaAddrs = []string{"127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3"}
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if len(client.load().replicas) != len(cfg.ReplicaAddrs) {
+			t.Fatalf("expected %d replicas, got %d", len(cfg.ReplicaAddrs), len(client.load().replicas))
+		}
+
+		for i := 0; i < 2*len(cfg.ReplicaAddrs); i++ {
+			want := cfg.ReplicaAddrs[i%len(cfg.ReplicaAddrs)]
+			if got := client.ReadOnly().(*redis.Client).Options().Addr; got != want {
+				t.Errorf("call %d: expected replica %s, got %s", i, want, got)
+			}
+		}
+	})
+
+	primary := newTestClient(t)
+	cfg := DefaultConfig()
+	cfg.ReplicaAddrs = []string{primary.Options().Addr}
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	key := "rediskit:test:replica"
+	defer client.Del(ctx, key)
+	client.Set(ctx, key, "v", 0)
+	if got, err := client.ReadOnly().Get(ctx, key).Result(); err != nil || got != "v" {
+		t.Errorf("expected %q from the replica, got %q, %v", "v", got, err)
+	}
+}
@@ -0,0 +1,173 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// retryablePrefixes are server error prefixes for conditions that clear up
+// on their own, such as a replica loading its dataset or a failover
+var retryablePrefixes = []string{"LOADING", "READONLY", "MASTERDOWN", "TRYAGAIN", "CLUSTERDOWN"}
+
+// IsRetryable reports whether err is worth retrying: an optimistic
+// transaction that lost a WATCH race, a transient network failure, or a
+// server error that clears up on its own. Context errors are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, redis.TxFailedErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		msg := redisErr.Error()
+		for _, prefix := range retryablePrefixes {
+			if strings.HasPrefix(msg, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WithRetry calls fn, retrying up to MaxRetries times while it returns a
+// retryable error. Waits between attempts back off exponentially from
+// MinRetryBackoff, capped at MaxRetryBackoff, with random jitter when
+// RetryJitter is set. Non-retryable errors are returned immediately, and
+// the context error is returned if ctx is done while waiting.
+func (c *Client) WithRetry(ctx context.Context, fn func(ctx context.Context) error) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if fn == nil {
+		return fmt.Errorf("%w: retry function is nil", ErrInvalidArgument)
+	}
+
+	logger := st.config.Logger
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || !IsRetryable(err) {
+			return err
+		}
+		if attempt >= st.config.MaxRetries {
+			if logger != nil {
+				logger.Warnf("giving up after %d attempts: %v", attempt+1, err)
+			}
+			return err
+		}
+
+		backoff := c.nextRetry("WithRetry", attempt)
+		if logger != nil {
+			logger.Debugf("retrying in %s after attempt %d failed: %v", backoff, attempt+1, err)
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RetryStat is the retry activity of one operation
+type RetryStat struct {
+	Retries int64         // retries started
+	Backoff time.Duration // total wait before them
+}
+
+// RetryStats returns the retries made since the client was created by
+// operation, for metrics: "WithRetry", "Transaction", "Remember",
+// "SubscribeWithReconnect", and "ConsumeStream". Operations that never
+// retried are left out. The retries go-redis makes internally, governed
+// by MaxRetries, are not counted.
+func (c *Client) RetryStats() map[string]RetryStat {
+	stats := make(map[string]RetryStat)
+	c.retries.Range(func(op, v any) bool {
+		counter := v.(*retryCounter)
+		stats[op.(string)] = RetryStat{
+			Retries: counter.retries.Load(),
+			Backoff: time.Duration(counter.backoff.Load()),
+		}
+		return true
+	})
+	return stats
+}
+
+// retryCounter accumulates the retries of one operation for RetryStats
+type retryCounter struct {
+	retries atomic.Int64
+	backoff atomic.Int64 // nanoseconds
+}
+
+// nextRetry returns the wait before retry number attempt+1 of op and
+// counts it in RetryStats
+func (c *Client) nextRetry(op string, attempt int) time.Duration {
+	backoff := c.retryBackoff(attempt)
+	v, _ := c.retries.LoadOrStore(op, new(retryCounter))
+	counter := v.(*retryCounter)
+	counter.retries.Add(1)
+	counter.backoff.Add(int64(backoff))
+	return backoff
+}
+
+// retryBackoff returns the wait before retry number attempt+1, jittered
+// when RetryJitter is set
+func (c *Client) retryBackoff(attempt int) time.Duration {
+	st := c.load()
+	var jitter func(int64) int64
+	if st.config.RetryJitter {
+		jitter = rand.Int63n
+	}
+	return backoffDelay(attempt, st.config.MinRetryBackoff, st.config.MaxRetryBackoff, jitter)
+}
+
+// backoffDelay returns the wait before retry number attempt+1: minBackoff
+// doubled per attempt and capped at maxBackoff. With jitter, which returns
+// a random number in [0, n), the wait is drawn from the upper half of that
+// delay, never below minBackoff, so the ranges of successive attempts
+// only overlap near the cap and waits still grow until then.
+func backoffDelay(attempt int, minBackoff, maxBackoff time.Duration, jitter func(n int64) int64) time.Duration {
+	backoff := minBackoff
+	for i := 0; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = max(min(backoff, maxBackoff), 0)
+	if jitter == nil {
+		return backoff
+	}
+	low := max(backoff/2, minBackoff)
+	if low >= backoff {
+		return backoff
+	}
+	return low + time.Duration(jitter(int64(backoff-low)+1))
+}
+
+// retrySchedule returns the waits before each of n retries, jittered with
+// rng when it is not nil
+func retrySchedule(n int, minBackoff, maxBackoff time.Duration, rng *rand.Rand) []time.Duration {
+	var jitter func(int64) int64
+	if rng != nil {
+		jitter = rng.Int63n
+	}
+	schedule := make([]time.Duration, n)
+	for attempt := range schedule {
+		schedule[attempt] = backoffDelay(attempt, minBackoff, maxBackoff, jitter)
+	}
+	return schedule
+}
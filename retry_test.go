@@ -0,0 +1,220 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// serverError mimics an error reply from the server
+type serverError string
+
+func (e serverError) Error() string { return string(e) }
+func (e serverError) RedisError()   {}
+
+// TestIsRetryable tests classification of retryable errors
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"transaction failed", redis.TxFailedErr, true},
+		{"wrapped transaction failed", fmt.Errorf("update cart: %w", redis.TxFailedErr), true},
+		{"eof", io.EOF, true},
+		{"network error", &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}, true},
+		{"loading", serverError("LOADING Redis is loading the dataset in memory"), true},
+		{"readonly replica", serverError("READONLY You can't write against a read only replica."), true},
+		{"wrong type", serverError("WRONGTYPE Operation against a key holding the wrong kind of value"), false},
+		{"cache miss", redis.Nil, false},
+		{"cancelled", context.Canceled, false},
+		{"deadline", context.DeadlineExceeded, false},
+		{"application error", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWithRetry tests retrying operations with backoff
+func TestWithRetry(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.WithRetry(context.Background(), func(context.Context) error { return nil }); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	cfg := DefaultConfig()
+	cfg.MaxRetries = 3
+	cfg.MinRetryBackoff = time.Millisecond
+	cfg.MaxRetryBackoff = 4 * time.Millisecond
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	t.Run("nil function", func(t *testing.T) {
+		if err := client.WithRetry(context.Background(), nil); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	tests := []struct {
+		name      string
+		failures  int   // calls that fail before one succeeds
+		err       error // error returned by failing calls
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "succeeds first time", failures: 0, err: redis.TxFailedErr, wantCalls: 1},
+		{name: "succeeds after retries", failures: 2, err: redis.TxFailedErr, wantCalls: 3},
+		{name: "gives up after max retries", failures: 10, err: redis.TxFailedErr, wantCalls: 4, wantErr: true},
+		{name: "non-retryable error returns immediately", failures: 10, err: errors.New("boom"), wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := client.WithRetry(context.Background(), func(context.Context) error {
+				calls++
+				if calls <= tt.failures {
+					return tt.err
+				}
+				return nil
+			})
+			if calls != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, calls)
+			}
+			if tt.wantErr && !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	t.Run("honors cancellation between attempts", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := client.WithRetry(ctx, func(context.Context) error {
+			calls++
+			cancel()
+			return redis.TxFailedErr
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("expected 1 call, got %d", calls)
+		}
+	})
+
+	t.Run("counts retries by operation", func(t *testing.T) {
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if stats := client.RetryStats(); len(stats) != 0 {
+			t.Errorf("expected no retries yet, got %v", stats)
+		}
+
+		calls := 0
+		client.WithRetry(context.Background(), func(context.Context) error {
+			calls++
+			if calls <= 2 {
+				return redis.TxFailedErr
+			}
+			return nil
+		})
+		want := map[string]RetryStat{"WithRetry": {Retries: 2, Backoff: 3 * time.Millisecond}}
+		if got := client.RetryStats(); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("backoff grows and is capped", func(t *testing.T) {
+		want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond}
+		for attempt, w := range want {
+			if got := client.retryBackoff(attempt); got != w {
+				t.Errorf("attempt %d: expected %v, got %v", attempt, w, got)
+			}
+		}
+	})
+}
+
+// TestRetrySchedule tests jittered exponential retry waits
+func TestRetrySchedule(t *testing.T) {
+	const minBackoff, maxBackoff = 10 * time.Millisecond, 500 * time.Millisecond
+
+	t.Run("without jitter doubles up to the cap", func(t *testing.T) {
+		got := retrySchedule(7, minBackoff, maxBackoff, nil)
+		want := []time.Duration{
+			10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 80 * time.Millisecond,
+			160 * time.Millisecond, 320 * time.Millisecond, 500 * time.Millisecond,
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	for _, seed := range []int64{1, 2, 42, 1234} {
+		t.Run(fmt.Sprintf("jittered with seed %d", seed), func(t *testing.T) {
+			schedule := retrySchedule(10, minBackoff, maxBackoff, rand.New(rand.NewSource(seed)))
+			for i, d := range schedule {
+				if d < minBackoff || d > maxBackoff {
+					t.Errorf("delay %d: %v is outside [%v, %v]", i, d, minBackoff, maxBackoff)
+				}
+				// Waits grow until they reach the capped range
+				if i > 0 && d < schedule[i-1] && schedule[i-1] < maxBackoff/2 {
+					t.Errorf("delay %d: %v is shorter than the previous %v", i, d, schedule[i-1])
+				}
+			}
+			if reflect.DeepEqual(schedule, retrySchedule(10, minBackoff, maxBackoff, nil)) {
+				t.Error("expected jitter to change the schedule")
+			}
+			again := retrySchedule(10, minBackoff, maxBackoff, rand.New(rand.NewSource(seed)))
+			if !reflect.DeepEqual(schedule, again) {
+				t.Error("expected the same seed to give the same schedule")
+			}
+		})
+	}
+
+	t.Run("equal bounds leave nothing to jitter", func(t *testing.T) {
+		for _, d := range retrySchedule(3, minBackoff, minBackoff, rand.New(rand.NewSource(1))) {
+			if d != minBackoff {
+				t.Errorf("expected %v, got %v", minBackoff, d)
+			}
+		}
+	})
+
+	t.Run("client applies RetryJitter", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.MinRetryBackoff = minBackoff
+		cfg.MaxRetryBackoff = maxBackoff
+		cfg.RetryJitter = true
+		client := clientWith(nil, cfg)
+		for attempt := 0; attempt < 10; attempt++ {
+			if d := client.retryBackoff(attempt); d < minBackoff || d > maxBackoff {
+				t.Errorf("attempt %d: %v is outside [%v, %v]", attempt, d, minBackoff, maxBackoff)
+			}
+		}
+	})
+}
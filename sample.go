@@ -0,0 +1,69 @@
+package rediskit
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SRandN returns up to count random members of the set at key. A positive
+// count returns distinct members; a negative count may repeat members and
+// always returns -count of them. A missing key yields an empty slice.
+func (c *Client) SRandN(ctx context.Context, key string, count int64) ([]string, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	if err := c.checkReplySize(abs(count)); err != nil {
+		return nil, err
+	}
+	members, err := st.rdb.SRandMemberN(ctx, key, count).Result()
+	if err != nil {
+		return nil, err
+	}
+	if members == nil {
+		members = []string{}
+	}
+	return members, nil
+}
+
+// ZRandN returns up to count random members of the sorted set at key, with
+// the same count semantics as SRandN. Scores are filled in only when
+// withScores is set. A missing key yields an empty slice.
+func (c *Client) ZRandN(ctx context.Context, key string, count int64, withScores bool) ([]redis.Z, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	if err := c.checkReplySize(abs(count)); err != nil {
+		return nil, err
+	}
+
+	if withScores {
+		zs, err := st.rdb.ZRandMemberWithScores(ctx, key, int(count)).Result()
+		if err != nil {
+			return nil, err
+		}
+		if zs == nil {
+			zs = []redis.Z{}
+		}
+		return zs, nil
+	}
+
+	members, err := st.rdb.ZRandMember(ctx, key, int(count)).Result()
+	if err != nil {
+		return nil, err
+	}
+	zs := make([]redis.Z, len(members))
+	for i, m := range members {
+		zs[i] = redis.Z{Member: m}
+	}
+	return zs, nil
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
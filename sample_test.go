@@ -0,0 +1,118 @@
+package rediskit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestSRandN tests random set member sampling
+func TestSRandN(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.SRandN(context.Background(), "k", 1); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:srand"
+	client.Del(ctx, key)
+	defer client.Del(ctx, key)
+	client.SAdd(ctx, key, "a", "b", "c")
+
+	t.Run("distinct members", func(t *testing.T) {
+		members, err := client.SRandN(ctx, key, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(members) != 3 {
+			t.Errorf("expected all 3 members, got %v", members)
+		}
+		seen := map[string]bool{}
+		for _, m := range members {
+			if seen[m] {
+				t.Errorf("duplicate member %q with positive count", m)
+			}
+			seen[m] = true
+		}
+	})
+
+	t.Run("negative count allows duplicates", func(t *testing.T) {
+		members, err := client.SRandN(ctx, key, -10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(members) != 10 {
+			t.Errorf("expected 10 members, got %d", len(members))
+		}
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		members, err := client.SRandN(ctx, key+":missing", 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if members == nil || len(members) != 0 {
+			t.Errorf("expected empty slice, got %#v", members)
+		}
+	})
+}
+
+// TestZRandN tests random sorted set member sampling
+func TestZRandN(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.ZRandN(context.Background(), "k", 1, false); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:zrand"
+	client.Del(ctx, key)
+	defer client.Del(ctx, key)
+
+	scores := map[string]float64{"a": 1, "b": 2, "c": 3}
+	for m, s := range scores {
+		client.ZAdd(ctx, key, redis.Z{Member: m, Score: s})
+	}
+
+	t.Run("with scores", func(t *testing.T) {
+		zs, err := client.ZRandN(ctx, key, 2, true)
+		if err != nil {
+			t.Skipf("ZRANDMEMBER not available: %v", err)
+		}
+		if len(zs) != 2 {
+			t.Fatalf("expected 2 members, got %v", zs)
+		}
+		for _, z := range zs {
+			if scores[z.Member.(string)] != z.Score {
+				t.Errorf("member %v has score %v, want %v", z.Member, z.Score, scores[z.Member.(string)])
+			}
+		}
+	})
+
+	t.Run("without scores", func(t *testing.T) {
+		zs, err := client.ZRandN(ctx, key, 3, false)
+		if err != nil {
+			t.Skipf("ZRANDMEMBER not available: %v", err)
+		}
+		if len(zs) != 3 {
+			t.Errorf("expected 3 members, got %v", zs)
+		}
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		zs, err := client.ZRandN(ctx, key+":missing", 3, true)
+		if err != nil {
+			t.Skipf("ZRANDMEMBER not available: %v", err)
+		}
+		if zs == nil || len(zs) != 0 {
+			t.Errorf("expected empty slice, got %#v", zs)
+		}
+	})
+}
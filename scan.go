@@ -0,0 +1,182 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// KeyIterator iterates over keys matching a pattern with SCAN, fetching one
+// batch per round trip
+type KeyIterator struct {
+	client *Client
+	ctx    context.Context
+	match  string
+	count  int64
+
+	cursor uint64
+	page   []string
+	done   bool
+	val    string
+	err    error
+}
+
+// ScanKeys returns an iterator over the keys matching match, with KeyPrefix
+// prepended to the pattern. Keys are returned as stored, including the
+// prefix. count is the SCAN COUNT hint; 0 uses a default. The context is
+// checked before each batch, so cancelling it stops the iteration. SCAN
+// may return a key more than once if the keyspace changes meanwhile.
+func (c *Client) ScanKeys(ctx context.Context, match string, count int64) (*KeyIterator, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("%w: count must not be negative", ErrInvalidArgument)
+	}
+	if count == 0 {
+		count = defaultScanBatchSize
+	}
+	if match == "" {
+		match = "*"
+	}
+	return &KeyIterator{
+		client: c,
+		ctx:    ctx,
+		match:  st.config.KeyPrefix + match,
+		count:  count,
+	}, nil
+}
+
+// Next advances to the next key, returning false when the iteration is
+// finished or failed
+func (it *KeyIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		if it.err = it.ctx.Err(); it.err != nil {
+			return false
+		}
+		it.page, it.cursor, it.err = it.client.load().rdb.Scan(it.ctx, it.cursor, it.match, it.count).Result()
+		if it.err != nil {
+			return false
+		}
+		it.done = it.cursor == 0
+	}
+	it.val, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Val returns the current key
+func (it *KeyIterator) Val() string {
+	return it.val
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *KeyIterator) Err() error {
+	return it.err
+}
+
+// ScanKeysCallback calls fn for each key matching match, as ScanKeys
+// would return them, stopping at and returning the first error from fn
+func (c *Client) ScanKeysCallback(ctx context.Context, match string, count int64, fn func(key string) error) error {
+	if fn == nil {
+		return fmt.Errorf("%w: callback is nil", ErrInvalidArgument)
+	}
+	it, err := c.ScanKeys(ctx, match, count)
+	if err != nil {
+		return err
+	}
+	for it.Next() {
+		if err := fn(it.Val()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// defaultDeleteBatchSize is how many keys DeleteByPattern unlinks per round
+// trip unless DeleteBatchSize says otherwise
+const defaultDeleteBatchSize = 500
+
+// deleteOptions holds the settings for DeleteByPattern
+type deleteOptions struct {
+	batchSize      int
+	allowDangerous bool
+}
+
+// DeleteOption configures DeleteByPattern
+type DeleteOption func(*deleteOptions)
+
+// DeleteBatchSize sets how many keys are unlinked per round trip
+func DeleteBatchSize(n int) DeleteOption {
+	return func(o *deleteOptions) { o.batchSize = n }
+}
+
+// AllowDangerous lets DeleteByPattern run with a match-everything pattern
+// and no KeyPrefix, deleting every key in the database
+func AllowDangerous() DeleteOption {
+	return func(o *deleteOptions) { o.allowDangerous = true }
+}
+
+// DeleteByPattern deletes the keys matching match, found with ScanKeys,
+// using pipelined UNLINK batches so the server frees memory in the
+// background. It returns the number of keys removed, including on error
+// or cancellation. Without a KeyPrefix, an empty or "*" pattern is refused
+// unless AllowDangerous is passed.
+func (c *Client) DeleteByPattern(ctx context.Context, match string, opts ...DeleteOption) (int64, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return 0, ErrNilClient
+	}
+	o := deleteOptions{batchSize: defaultDeleteBatchSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.batchSize <= 0 {
+		return 0, fmt.Errorf("%w: batch size must be greater than 0", ErrInvalidArgument)
+	}
+	if st.config.KeyPrefix == "" && (match == "" || match == "*") && !o.allowDangerous {
+		return 0, fmt.Errorf("%w: pattern %q would delete every key; pass AllowDangerous to confirm", ErrInvalidArgument, match)
+	}
+
+	it, err := c.ScanKeys(ctx, match, int64(o.batchSize))
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int64
+	batch := make([]string, 0, o.batchSize)
+	flush := func() error {
+		pipe := st.rdb.Pipeline()
+		unlinks := make([]*redis.IntCmd, len(batch))
+		for i, key := range batch {
+			unlinks[i] = pipe.Unlink(ctx, key)
+		}
+		_, err := pipe.Exec(ctx)
+		for _, cmd := range unlinks {
+			deleted += cmd.Val()
+		}
+		batch = batch[:0]
+		return err
+	}
+
+	for it.Next() {
+		if batch = append(batch, it.Val()); len(batch) == o.batchSize {
+			if err := flush(); err != nil {
+				return deleted, err
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return deleted, err
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
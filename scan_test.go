@@ -0,0 +1,208 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// TestScanKeys tests iterating over keys with SCAN
+func TestScanKeys(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.ScanKeys(context.Background(), "*", 10); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.ScanKeys(context.Background(), "*", -1); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("negative count: expected ErrInvalidArgument, got %v", err)
+		}
+		if err := client.ScanKeysCallback(context.Background(), "*", 10, nil); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("nil callback: expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	prefix := "rediskit:test:scan:"
+	var want []string
+	for i := 0; i < 25; i++ {
+		key := fmt.Sprintf("%s%02d", prefix, i)
+		client.Set(ctx, key, i, 0)
+		want = append(want, key)
+	}
+	defer client.Del(ctx, want...)
+
+	collect := func(t *testing.T, it *KeyIterator) []string {
+		t.Helper()
+		seen := make(map[string]bool)
+		for it.Next() {
+			seen[it.Val()] = true
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("iteration failed: %v", err)
+		}
+		keys := make([]string, 0, len(seen))
+		for k := range seen {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+
+	t.Run("iterates all matching keys", func(t *testing.T) {
+		it, err := client.ScanKeys(ctx, prefix+"*", 7)
+		if err != nil {
+			t.Fatalf("ScanKeys: %v", err)
+		}
+		if got := collect(t, it); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("applies key prefix", func(t *testing.T) {
+		cfg := *client.GetConfig()
+		cfg.KeyPrefix = prefix
+		prefixed := clientWith(client.Client, &cfg)
+
+		it, err := prefixed.ScanKeys(ctx, "1*", 0)
+		if err != nil {
+			t.Fatalf("ScanKeys: %v", err)
+		}
+		got := collect(t, it)
+		if len(got) != 10 || got[0] != prefix+"10" {
+			t.Errorf("expected the 10 keys under %s1*, got %v", prefix, got)
+		}
+	})
+
+	t.Run("stops when cancelled", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		it, err := client.ScanKeys(cctx, prefix+"*", 10)
+		if err != nil {
+			t.Fatalf("ScanKeys: %v", err)
+		}
+		if it.Next() {
+			t.Error("expected no keys after cancellation")
+		}
+		if !errors.Is(it.Err(), context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", it.Err())
+		}
+	})
+
+	t.Run("callback stops on error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		calls := 0
+		err := client.ScanKeysCallback(ctx, prefix+"*", 5, func(string) error {
+			calls++
+			if calls == 3 {
+				return errStop
+			}
+			return nil
+		})
+		if !errors.Is(err, errStop) {
+			t.Errorf("expected callback error, got %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 calls, got %d", calls)
+		}
+	})
+}
+
+// TestDeleteByPattern tests bulk deletion with SCAN and UNLINK
+func TestDeleteByPattern(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.DeleteByPattern(context.Background(), "k:*"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("guards against deleting everything", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		for _, match := range []string{"", "*"} {
+			if _, err := client.DeleteByPattern(context.Background(), match); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("pattern %q: expected ErrInvalidArgument, got %v", match, err)
+			}
+		}
+		if _, err := client.DeleteByPattern(context.Background(), "k:*", DeleteBatchSize(0)); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("zero batch size: expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	prefix := "rediskit:test:delete-pattern:"
+	keep := "rediskit:test:delete-pattern-keep"
+
+	seed := func(n int) {
+		for i := 0; i < n; i++ {
+			client.Set(ctx, fmt.Sprintf("%s%d", prefix, i), i, 0)
+		}
+		client.Set(ctx, keep, "v", 0)
+	}
+	defer client.Del(ctx, keep)
+
+	t.Run("deletes matching keys in batches", func(t *testing.T) {
+		seed(23)
+		deleted, err := client.DeleteByPattern(ctx, prefix+"*", DeleteBatchSize(5))
+		if err != nil {
+			t.Fatalf("DeleteByPattern: %v", err)
+		}
+		if deleted != 23 {
+			t.Errorf("expected 23 deleted, got %d", deleted)
+		}
+		if n := len(client.Keys(ctx, prefix+"*").Val()); n != 0 {
+			t.Errorf("expected no matching keys left, got %d", n)
+		}
+		if client.Exists(ctx, keep).Val() != 1 {
+			t.Error("expected non-matching key to survive")
+		}
+	})
+
+	t.Run("star is scoped by key prefix", func(t *testing.T) {
+		seed(3)
+		cfg := *client.GetConfig()
+		cfg.KeyPrefix = prefix
+		prefixed := clientWith(client.Client, &cfg)
+
+		deleted, err := prefixed.DeleteByPattern(ctx, "*")
+		if err != nil {
+			t.Fatalf("DeleteByPattern: %v", err)
+		}
+		if deleted != 3 {
+			t.Errorf("expected 3 deleted, got %d", deleted)
+		}
+		if client.Exists(ctx, keep).Val() != 1 {
+			t.Error("expected key outside the prefix to survive")
+		}
+	})
+
+	t.Run("cancellation returns progress", func(t *testing.T) {
+		seed(3)
+		defer client.DeleteByPattern(ctx, prefix+"*")
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		deleted, err := client.DeleteByPattern(cctx, prefix+"*")
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+		if deleted != 0 {
+			t.Errorf("expected nothing deleted, got %d", deleted)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package rediskit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ScriptRegistry holds the Lua scripts registered on a client by name
+type ScriptRegistry struct {
+	client *Client
+
+	mu      sync.RWMutex
+	scripts map[string]*Script
+}
+
+// Script is a registered Lua script. It runs by SHA with EVALSHA and only
+// sends its source with EVAL when the server does not have it cached yet.
+type Script struct {
+	name   string
+	script *redis.Script
+	client *Client
+}
+
+// Scripts returns the client's script registry
+func (c *Client) Scripts() *ScriptRegistry {
+	c.scriptsOnce.Do(func() {
+		c.scripts = &ScriptRegistry{client: c, scripts: make(map[string]*Script)}
+	})
+	return c.scripts
+}
+
+// RegisterScript registers the Lua source src under name, replacing any
+// script registered under that name before, and returns it. Its SHA is
+// computed locally, so registering does not contact the server.
+func (r *ScriptRegistry) RegisterScript(name, src string) *Script {
+	s := &Script{name: name, script: redis.NewScript(src), client: r.client}
+	r.mu.Lock()
+	r.scripts[name] = s
+	r.mu.Unlock()
+	return s
+}
+
+// Get returns the script registered under name, or nil if there is none
+func (r *ScriptRegistry) Get(name string) *Script {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.scripts[name]
+}
+
+// Name returns the name the script was registered under
+func (s *Script) Name() string {
+	return s.name
+}
+
+// Hash returns the script's SHA1 digest as used by EVALSHA
+func (s *Script) Hash() string {
+	return s.script.Hash()
+}
+
+// Run runs the script with EVALSHA, falling back to EVAL when the server
+// replies NOSCRIPT, which also caches the script there for later runs
+func (s *Script) Run(ctx context.Context, keys []string, args ...any) (any, error) {
+	rdb := s.client.load().rdb
+	if rdb == nil {
+		return nil, ErrNilClient
+	}
+	return s.script.Run(ctx, rdb, keys, args...).Result()
+}
@@ -0,0 +1,113 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestScriptRegistry tests registering and running Lua scripts
+func TestScriptRegistry(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		s := client.Scripts().RegisterScript("noop", "return 1")
+		if _, err := s.Run(context.Background(), nil); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("registry lookup", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if client.Scripts() != client.Scripts() {
+			t.Error("expected one registry per client")
+		}
+		first := client.Scripts().RegisterScript("answer", "return 41")
+		if got := client.Scripts().Get("answer"); got != first {
+			t.Error("expected Get to return the registered script")
+		}
+		second := client.Scripts().RegisterScript("answer", "return 42")
+		if got := client.Scripts().Get("answer"); got != second || got.Hash() == first.Hash() {
+			t.Error("expected re-registering to replace the script")
+		}
+		if second.Name() != "answer" {
+			t.Errorf("expected name answer, got %q", second.Name())
+		}
+		if client.Scripts().Get("missing") != nil {
+			t.Error("expected nil for an unregistered name")
+		}
+	})
+
+	newTestClient(t) // skips without Redis
+	client, err := NewClient(nil)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var sent []string
+	client.AddHook(commandRecorder(func(name string) {
+		mu.Lock()
+		sent = append(sent, name)
+		mu.Unlock()
+	}))
+	commands := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		cmds := sent
+		sent = nil
+		return cmds
+	}
+
+	// A unique source guarantees the server has not cached the script yet
+	src := fmt.Sprintf("-- %d\nreturn redis.call('INCRBY', KEYS[1], ARGV[1])", time.Now().UnixNano())
+	script := client.Scripts().RegisterScript("incrby", src)
+	key := "rediskit:test:script:counter"
+	client.Del(ctx, key)
+	defer client.Del(ctx, key)
+	commands()
+
+	got, err := script.Run(ctx, []string{key}, 2)
+	if err != nil {
+		t.Fatalf("first Run: %v", err)
+	}
+	if got != int64(2) {
+		t.Errorf("expected 2, got %v", got)
+	}
+	if cmds := commands(); !reflect.DeepEqual(cmds, []string{"evalsha", "eval"}) {
+		t.Errorf("expected the first run to fall back to EVAL, got %v", cmds)
+	}
+
+	got, err = script.Run(ctx, []string{key}, 3)
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if got != int64(5) {
+		t.Errorf("expected 5, got %v", got)
+	}
+	if cmds := commands(); !reflect.DeepEqual(cmds, []string{"evalsha"}) {
+		t.Errorf("expected the second run to use only the cached SHA, got %v", cmds)
+	}
+}
+
+// commandRecorder reports the name of every command sent
+type commandRecorder func(name string)
+
+func (h commandRecorder) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h commandRecorder) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h(cmd.Name())
+		return next(ctx, cmd)
+	}
+}
+
+func (h commandRecorder) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
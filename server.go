@@ -0,0 +1,138 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConfigGet returns the server configuration parameters matching param.
+// The parameter may be a glob pattern such as "maxmemory*".
+func (c *Client) ConfigGet(ctx context.Context, param string) (map[string]string, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	res, err := st.rdb.ConfigGet(ctx, param).Result()
+	if err != nil {
+		return nil, wrapConfigErr(err)
+	}
+	return res, nil
+}
+
+// ConfigSet sets a server configuration parameter at runtime
+func (c *Client) ConfigSet(ctx context.Context, param, value string) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	return wrapConfigErr(st.rdb.ConfigSet(ctx, param, value).Err())
+}
+
+// wrapConfigErr maps the error returned for a disabled or renamed CONFIG
+// command to ErrConfigDisabled
+func wrapConfigErr(err error) error {
+	if isUnknownCommand(err) {
+		return fmt.Errorf("%w: %v", ErrConfigDisabled, err)
+	}
+	return err
+}
+
+// ServerInfo is the parsed reply of INFO. Fields the server does not
+// report are left at their zero value.
+type ServerInfo struct {
+	Version                string        // redis_version
+	Uptime                 time.Duration // uptime_in_seconds
+	UsedMemory             int64         // used_memory, in bytes
+	ConnectedClients       int64         // connected_clients
+	TotalCommandsProcessed int64         // total_commands_processed
+	KeyspaceHits           int64         // keyspace_hits
+	KeyspaceMisses         int64         // keyspace_misses
+	Role                   string        // "master" or "slave"
+
+	// Extra holds every other field by name, such as "maxmemory" or the
+	// per-database "db0" keyspace line, and any of the above whose value
+	// could not be parsed
+	Extra map[string]string
+}
+
+// ServerInfo runs INFO with its default sections and parses the reply
+func (c *Client) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	info, err := st.rdb.Info(ctx).Result()
+	if err != nil {
+		return nil, err
+	}
+	return parseServerInfo(info), nil
+}
+
+// parseServerInfo parses an INFO reply into a ServerInfo
+func parseServerInfo(info string) *ServerInfo {
+	fields := parseInfo(info)
+	si := &ServerInfo{}
+
+	take := func(name string, dest *string) {
+		if v, ok := fields[name]; ok {
+			*dest = v
+			delete(fields, name)
+		}
+	}
+	takeInt := func(name string, dest *int64) {
+		if n, err := strconv.ParseInt(fields[name], 10, 64); err == nil {
+			*dest = n
+			delete(fields, name)
+		}
+	}
+
+	var uptime int64
+	take("redis_version", &si.Version)
+	take("role", &si.Role)
+	takeInt("uptime_in_seconds", &uptime)
+	takeInt("used_memory", &si.UsedMemory)
+	takeInt("connected_clients", &si.ConnectedClients)
+	takeInt("total_commands_processed", &si.TotalCommandsProcessed)
+	takeInt("keyspace_hits", &si.KeyspaceHits)
+	takeInt("keyspace_misses", &si.KeyspaceMisses)
+	si.Uptime = time.Duration(uptime) * time.Second
+	si.Extra = fields
+	return si
+}
+
+// parseInfo parses an INFO reply into its field/value pairs, skipping
+// section headers and lines it does not understand
+func parseInfo(info string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// isUnknownCommand reports whether err is the server's reply to a command
+// it does not know, which is also what disabled or renamed commands return
+func isUnknownCommand(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "ERR unknown command")
+}
+
+// isUnknownSubcommand reports whether err is the server's reply to a
+// subcommand it does not know, such as MEMORY USAGE on a server without it
+func isUnknownSubcommand(err error) bool {
+	return err != nil && strings.HasPrefix(strings.ToLower(err.Error()), "err unknown subcommand")
+}
+
+// isWrongArity reports whether err is the server rejecting a command's
+// argument count, as older servers do for newer optional arguments
+func isWrongArity(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "ERR wrong number of arguments")
+}
@@ -0,0 +1,153 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestWrapConfigErr tests classification of disabled CONFIG errors
+func TestWrapConfigErr(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		disabled bool
+	}{
+		{"nil", nil, false},
+		{"unknown command", errors.New("ERR unknown command 'CONFIG', with args beginning with: 'GET' "), true},
+		{"other error", errors.New("ERR Unknown option or number of arguments for CONFIG SET - 'foo'"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := wrapConfigErr(tt.err)
+			if got := errors.Is(err, ErrConfigDisabled); got != tt.disabled {
+				t.Errorf("errors.Is(ErrConfigDisabled): got %v, want %v", got, tt.disabled)
+			}
+			if tt.err == nil && err != nil {
+				t.Errorf("expected nil error, got %v", err)
+			}
+		})
+	}
+}
+
+// TestParseInfo tests parsing INFO replies
+func TestParseInfo(t *testing.T) {
+	info := "# Stats\r\nkeyspace_hits:12\r\nkeyspace_misses:3\r\n\r\n# Replication\r\nrole:master\r\ngarbage\r\n"
+	got := parseInfo(info)
+	want := map[string]string{"keyspace_hits": "12", "keyspace_misses": "3", "role": "master"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s: expected %q, got %q", k, v, got[k])
+		}
+	}
+}
+
+// TestConfigGetSet tests reading and writing server configuration
+func TestConfigGetSet(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.ConfigGet(context.Background(), "*"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+		if err := client.ConfigSet(context.Background(), "timeout", "0"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("with valid client", func(t *testing.T) {
+		client := newTestClient(t)
+		ctx := context.Background()
+
+		params, err := client.ConfigGet(ctx, "maxmemory*")
+		if errors.Is(err, ErrConfigDisabled) {
+			t.Skip("CONFIG is disabled on this server")
+		}
+		if err != nil {
+			t.Fatalf("ConfigGet: %v", err)
+		}
+		if _, ok := params["maxmemory-policy"]; !ok {
+			t.Errorf("expected maxmemory-policy in %v", params)
+		}
+
+		policy := params["maxmemory-policy"]
+		if err := client.ConfigSet(ctx, "maxmemory-policy", policy); err != nil {
+			t.Errorf("ConfigSet: %v", err)
+		}
+	})
+}
+
+// TestParseServerInfo tests field extraction from a canned INFO reply
+func TestParseServerInfo(t *testing.T) {
+	info := "# Server\r\nredis_version:7.2.4\r\nuptime_in_seconds:3600\r\n\r\n" +
+		"# Clients\r\nconnected_clients:5\r\n\r\n" +
+		"# Memory\r\nused_memory:1048576\r\nmaxmemory:0\r\n\r\n" +
+		"# Stats\r\ntotal_commands_processed:987\r\nkeyspace_hits:12\r\nkeyspace_misses:3\r\n\r\n" +
+		"# Replication\r\nrole:master\r\n\r\n" +
+		"# Keyspace\r\ndb0:keys=2,expires=0,avg_ttl=0\r\n"
+
+	got := parseServerInfo(info)
+	want := ServerInfo{
+		Version:                "7.2.4",
+		Uptime:                 time.Hour,
+		UsedMemory:             1048576,
+		ConnectedClients:       5,
+		TotalCommandsProcessed: 987,
+		KeyspaceHits:           12,
+		KeyspaceMisses:         3,
+		Role:                   "master",
+	}
+	if got.Version != want.Version || got.Uptime != want.Uptime ||
+		got.UsedMemory != want.UsedMemory || got.ConnectedClients != want.ConnectedClients ||
+		got.TotalCommandsProcessed != want.TotalCommandsProcessed ||
+		got.KeyspaceHits != want.KeyspaceHits || got.KeyspaceMisses != want.KeyspaceMisses ||
+		got.Role != want.Role {
+		t.Errorf("expected %+v, got %+v", want, *got)
+	}
+
+	wantExtra := map[string]string{"maxmemory": "0", "db0": "keys=2,expires=0,avg_ttl=0"}
+	if len(got.Extra) != len(wantExtra) {
+		t.Fatalf("expected extra %v, got %v", wantExtra, got.Extra)
+	}
+	for k, v := range wantExtra {
+		if got.Extra[k] != v {
+			t.Errorf("extra %s: expected %q, got %q", k, v, got.Extra[k])
+		}
+	}
+
+	t.Run("unparseable value is kept in extra", func(t *testing.T) {
+		got := parseServerInfo("used_memory:lots\r\n")
+		if got.UsedMemory != 0 || got.Extra["used_memory"] != "lots" {
+			t.Errorf("expected raw used_memory in extra, got %+v", got)
+		}
+	})
+}
+
+// TestServerInfo tests reading INFO from the server
+func TestServerInfo(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.ServerInfo(context.Background()); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("with valid client", func(t *testing.T) {
+		client := newTestClient(t)
+
+		info, err := client.ServerInfo(context.Background())
+		if err != nil {
+			t.Fatalf("ServerInfo: %v", err)
+		}
+		if info.ConnectedClients < 1 {
+			t.Errorf("expected at least one connected client, got %+v", info)
+		}
+		if info.Extra == nil {
+			t.Error("expected non-nil Extra")
+		}
+	})
+}
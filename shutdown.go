@@ -0,0 +1,208 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Shutdown stops the client in phases, all bounded by ctx:
+//
+//  1. background loops such as StartHealthMonitor stop and the
+//     duplicate-client registration is released;
+//  2. new commands are refused with redis.ErrClosed while those already
+//     running drain;
+//  3. locks still held through this client are released, best effort;
+//  4. the connection pool is closed.
+//
+// The pool is closed even if ctx is done first. Failures of individual
+// phases are joined into the returned error.
+func (c *Client) Shutdown(ctx context.Context) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	var errs []error
+
+	c.stopBackground()
+	c.markClosed()
+
+	select {
+	case <-c.inflight.close():
+	case <-ctx.Done():
+		errs = append(errs, fmt.Errorf("shutdown before in-flight commands drained: %w", ctx.Err()))
+	}
+
+	if err := c.releaseLocks(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := c.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// backgroundContext returns a context for a background loop that is also
+// cancelled when the client shuts down or closes
+func (c *Client) backgroundContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if c.background == nil {
+		return ctx, cancel
+	}
+	stop := context.AfterFunc(c.background, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// stopBackground cancels every context handed out by backgroundContext
+func (c *Client) stopBackground() {
+	if c.stop != nil {
+		c.stop()
+	}
+}
+
+// releaseLocks unlocks the locks still held through this client. Locks
+// that already expired are skipped; they need no release. The rest are
+// deleted only if their key still holds this client's token, so a lock
+// that another owner has since taken is left alone.
+func (c *Client) releaseLocks(ctx context.Context) error {
+	// Unlocking must get past the hook refusing new commands
+	ctx = context.WithValue(ctx, inflightKey{}, true)
+
+	var errs []error
+	now := time.Now().UnixNano()
+	c.locks.Range(func(key, deadline any) bool {
+		lock := key.(*Lock)
+		if now >= deadline.(int64) {
+			lock.untrack()
+			return true
+		}
+		if err := lock.Unlock(ctx); err != nil && !errors.Is(err, ErrLockNotHeld) {
+			errs = append(errs, fmt.Errorf("release lock %q: %w", lock.key, err))
+		}
+		return true
+	})
+	return errors.Join(errs...)
+}
+
+// inflightTracker counts running commands and refuses new ones once
+// closed. The count and the closing flag share one atomic word, so
+// admitting a command takes a single compare-and-swap.
+type inflightTracker struct {
+	state   atomic.Int64 // running commands, plus inflightClosing once closed
+	once    sync.Once
+	drained chan struct{} // closed when the count reaches zero after close
+}
+
+const inflightClosing = 1 << 62
+
+// acquire registers a command, reporting false once the tracker is closed
+func (t *inflightTracker) acquire() bool {
+	for {
+		v := t.state.Load()
+		if v&inflightClosing != 0 {
+			return false
+		}
+		if t.state.CompareAndSwap(v, v+1) {
+			return true
+		}
+	}
+}
+
+func (t *inflightTracker) release() {
+	if t.state.Add(-1) == inflightClosing {
+		close(t.drained)
+	}
+}
+
+// running returns the number of commands currently registered
+func (t *inflightTracker) running() int64 {
+	return t.state.Load() &^ inflightClosing
+}
+
+// close stops new acquisitions and returns a channel that is closed once
+// every running command has been released
+func (t *inflightTracker) close() <-chan struct{} {
+	t.once.Do(func() {
+		t.drained = make(chan struct{})
+		if t.state.Add(inflightClosing) == inflightClosing {
+			close(t.drained)
+		}
+	})
+	return t.drained
+}
+
+// inflightKey marks a context whose commands run on behalf of work that is
+// already tracked, such as a revalidation or releasing locks, so they are
+// not refused once the tracker is closed
+type inflightKey struct{}
+
+// handshakeCommands are the commands go-redis sends to set up a new
+// connection. A command admitted before Shutdown may still need one while
+// draining, so these are never refused.
+var handshakeCommands = map[string]bool{
+	"hello":    true,
+	"auth":     true,
+	"select":   true,
+	"client":   true,
+	"readonly": true,
+}
+
+// inflightHook tracks commands and pipelines for Shutdown. Admitted
+// commands run on the caller's context unchanged; the refused path is the
+// only one that looks at it.
+type inflightHook struct {
+	tracker *inflightTracker
+}
+
+func (h inflightHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h inflightHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if h.tracker.acquire() {
+			defer h.tracker.release()
+			return next(ctx, cmd)
+		}
+		if handshakeCommands[cmd.Name()] || ctx.Value(inflightKey{}) != nil {
+			return next(ctx, cmd)
+		}
+		cmd.SetErr(redis.ErrClosed)
+		return redis.ErrClosed
+	}
+}
+
+func (h inflightHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if h.tracker.acquire() {
+			defer h.tracker.release()
+			return next(ctx, cmds)
+		}
+		if ctx.Value(inflightKey{}) != nil || allHandshake(cmds) {
+			return next(ctx, cmds)
+		}
+		for _, cmd := range cmds {
+			cmd.SetErr(redis.ErrClosed)
+		}
+		return redis.ErrClosed
+	}
+}
+
+// allHandshake reports whether cmds only sets up a connection
+func allHandshake(cmds []redis.Cmder) bool {
+	for _, cmd := range cmds {
+		if !handshakeCommands[cmd.Name()] {
+			return false
+		}
+	}
+	return true
+}
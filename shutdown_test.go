@@ -0,0 +1,206 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestShutdown tests draining in-flight commands before closing
+func TestShutdown(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.Shutdown(context.Background()); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	newClient := func(t *testing.T) *Client {
+		newTestClient(t) // skips without Redis
+		// Without idle connection warmup only the test's own commands run
+		cfg := DefaultConfig()
+		cfg.MinIdleConns = 0
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		return client
+	}
+
+	// startBlocking runs a BLPOP on an empty list and waits until it is in flight
+	startBlocking := func(t *testing.T, client *Client, timeout time.Duration) <-chan error {
+		t.Helper()
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- client.BLPop(context.Background(), timeout, "rediskit:test:shutdown:empty").Err()
+		}()
+		deadline := time.Now().Add(time.Second)
+		for {
+			if client.inflight.running() > 0 {
+				return errCh
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("blocking command never started")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	t.Run("idle client closes", func(t *testing.T) {
+		client := newClient(t)
+		if err := client.Shutdown(context.Background()); err != nil {
+			t.Fatalf("Shutdown: %v", err)
+		}
+		if err := client.Ping(context.Background()).Err(); !errors.Is(err, redis.ErrClosed) {
+			t.Errorf("expected redis.ErrClosed after shutdown, got %v", err)
+		}
+	})
+
+	t.Run("waits for in-flight commands", func(t *testing.T) {
+		client := newClient(t)
+		errCh := startBlocking(t, client, time.Second)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := client.Shutdown(ctx); err != nil {
+			t.Fatalf("Shutdown: %v", err)
+		}
+		if err := <-errCh; err != redis.Nil {
+			t.Errorf("expected in-flight BLPOP to time out normally, got %v", err)
+		}
+	})
+
+	t.Run("rejects new commands while draining", func(t *testing.T) {
+		client := newClient(t)
+
+		// Hold a slot as an in-flight command that never finishes
+		if !client.inflight.acquire() {
+			t.Fatal("expected a fresh client to accept commands")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		shutdownErr := make(chan error, 1)
+		go func() { shutdownErr <- client.Shutdown(ctx) }()
+
+		// Wait for Shutdown to start refusing commands
+		for client.inflight.acquire() {
+			client.inflight.release()
+			time.Sleep(time.Millisecond)
+		}
+		if err := client.Ping(context.Background()).Err(); !errors.Is(err, redis.ErrClosed) {
+			t.Errorf("expected redis.ErrClosed while draining, got %v", err)
+		}
+		if _, err := client.Pipelined(context.Background(), func(p redis.Pipeliner) error {
+			p.Ping(context.Background())
+			return nil
+		}); !errors.Is(err, redis.ErrClosed) {
+			t.Errorf("expected redis.ErrClosed for pipeline while draining, got %v", err)
+		}
+		// An admitted command may need a new connection while draining
+		if err := client.Do(context.Background(), "client", "setname", "draining").Err(); err != nil {
+			t.Errorf("expected connection setup commands to pass while draining, got %v", err)
+		}
+
+		if err := <-shutdownErr; !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected wrapped deadline error, got %v", err)
+		}
+	})
+
+	t.Run("stops background loops and releases locks", func(t *testing.T) {
+		client := newClient(t)
+		ctx := context.Background()
+		held := "rediskit:test:shutdown:held"
+		taken := "rediskit:test:shutdown:taken"
+		client.Del(ctx, held, taken)
+		defer newTestClient(t).Del(ctx, held, taken)
+
+		events := client.StartHealthMonitor(ctx)
+		<-events // initial healthy event
+
+		if _, err := client.Lock(ctx, held, time.Minute); err != nil {
+			t.Fatalf("Lock: %v", err)
+		}
+		if _, err := client.Lock(ctx, taken, time.Minute); err != nil {
+			t.Fatalf("Lock: %v", err)
+		}
+		// Another owner takes over after this client's lock was lost
+		newTestClient(t).Set(ctx, taken, "other-owner", time.Minute)
+
+		if err := client.Shutdown(ctx); err != nil {
+			t.Fatalf("Shutdown: %v", err)
+		}
+
+		select {
+		case _, ok := <-events:
+			if ok {
+				t.Error("expected the health monitor channel to be closed")
+			}
+		case <-time.After(time.Second):
+			t.Error("health monitor kept running after shutdown")
+		}
+
+		if n := newTestClient(t).Exists(ctx, held).Val(); n != 0 {
+			t.Error("expected the held lock to be released")
+		}
+		if v := newTestClient(t).Get(ctx, taken).Val(); v != "other-owner" {
+			t.Errorf("expected the other owner's lock to survive, got %q", v)
+		}
+	})
+}
+
+// TestInflightTracker tests counting commands and draining them on close
+func TestInflightTracker(t *testing.T) {
+	var tracker inflightTracker
+	if !tracker.acquire() || !tracker.acquire() {
+		t.Fatal("expected an open tracker to admit commands")
+	}
+	drained := tracker.close()
+	if tracker.acquire() {
+		t.Error("expected a closed tracker to refuse commands")
+	}
+	if tracker.close() != drained {
+		t.Error("expected close to return the same channel every time")
+	}
+
+	tracker.release()
+	select {
+	case <-drained:
+		t.Fatal("drained with a command still running")
+	default:
+	}
+	if n := tracker.running(); n != 1 {
+		t.Errorf("expected 1 running command, got %d", n)
+	}
+	tracker.release()
+	select {
+	case <-drained:
+	default:
+		t.Fatal("expected the tracker to be drained")
+	}
+
+	var idle inflightTracker
+	select {
+	case <-idle.close():
+	default:
+		t.Error("expected an idle tracker to drain at once")
+	}
+}
+
+// BenchmarkInflightHook measures the cost Shutdown tracking adds per command
+func BenchmarkInflightHook(b *testing.B) {
+	hook := inflightHook{tracker: &inflightTracker{}}
+	process := hook.ProcessHook(func(context.Context, redis.Cmder) error { return nil })
+	ctx := context.Background()
+	cmd := redis.NewStatusCmd(ctx, "ping")
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			process(ctx, cmd)
+		}
+	})
+}
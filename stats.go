@@ -0,0 +1,67 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// PoolStats is a snapshot of connection pool statistics
+type PoolStats struct {
+	Hits         uint32        // times a free connection was found in the pool
+	Misses       uint32        // times a free connection was not found in the pool
+	Timeouts     uint32        // times a wait for a connection timed out
+	WaitCount    uint32        // times a caller had to wait for a connection
+	WaitDuration time.Duration // total time spent waiting for connections
+	TotalConns   uint32        // total connections in the pool
+	IdleConns    uint32        // idle connections in the pool
+	StaleConns   uint32        // stale connections removed from the pool
+}
+
+// PoolStats returns a snapshot of the underlying pool statistics. It
+// shadows redis.Client.PoolStats so callers only depend on this package's
+// type, and returns a zero snapshot when the client is nil.
+func (c *Client) PoolStats() *PoolStats {
+	st := c.load()
+	if st.rdb == nil {
+		return &PoolStats{}
+	}
+	s := st.rdb.PoolStats()
+	return &PoolStats{
+		Hits:         s.Hits,
+		Misses:       s.Misses,
+		Timeouts:     s.Timeouts,
+		WaitCount:    s.WaitCount,
+		WaitDuration: time.Duration(s.WaitDurationNs),
+		TotalConns:   s.TotalConns,
+		IdleConns:    s.IdleConns,
+		StaleConns:   s.StaleConns,
+	}
+}
+
+// StartStatsSampler calls fn with a fresh pool statistics snapshot every
+// interval until ctx is cancelled or the client shuts down
+func (c *Client) StartStatsSampler(ctx context.Context, interval time.Duration, fn func(PoolStats)) error {
+	if interval <= 0 {
+		return fmt.Errorf("%w: sampling interval must be greater than 0", ErrInvalidArgument)
+	}
+	if fn == nil {
+		return fmt.Errorf("%w: sampling callback is nil", ErrInvalidArgument)
+	}
+
+	ctx, cancel := c.backgroundContext(ctx)
+	go func() {
+		defer cancel()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fn(*c.PoolStats())
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,81 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestStartStatsSampler tests periodic pool statistics sampling
+func TestStartStatsSampler(t *testing.T) {
+	client, err := NewClient(nil)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		if err := client.StartStatsSampler(context.Background(), 0, func(PoolStats) {}); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("zero interval: expected ErrInvalidArgument, got %v", err)
+		}
+		if err := client.StartStatsSampler(context.Background(), time.Second, nil); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("nil callback: expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("samples until cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var samples int32
+		err := client.StartStatsSampler(ctx, 5*time.Millisecond, func(PoolStats) {
+			atomic.AddInt32(&samples, 1)
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+
+		got := atomic.LoadInt32(&samples)
+		if got == 0 {
+			t.Fatal("expected at least one sample")
+		}
+		time.Sleep(30 * time.Millisecond)
+		if after := atomic.LoadInt32(&samples); after != got {
+			t.Errorf("sampler kept running after cancel: %d -> %d samples", got, after)
+		}
+	})
+}
+
+// TestPoolStats tests that pool statistics are copied from the pool
+func TestPoolStats(t *testing.T) {
+	t.Run("nil client returns zero stats", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		stats := client.PoolStats()
+		if stats == nil || *stats != (PoolStats{}) {
+			t.Errorf("expected zero stats, got %+v", stats)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		if err := client.Ping(ctx).Err(); err != nil {
+			t.Fatalf("Ping: %v", err)
+		}
+	}
+
+	stats := client.PoolStats()
+	upstream := client.Client.PoolStats()
+	if stats.Hits == 0 || stats.TotalConns == 0 {
+		t.Errorf("expected hits and connections after pings, got %+v", stats)
+	}
+	if stats.Hits != upstream.Hits || stats.Misses != upstream.Misses ||
+		stats.Timeouts != upstream.Timeouts || stats.TotalConns != upstream.TotalConns ||
+		stats.IdleConns != upstream.IdleConns || stats.StaleConns != upstream.StaleConns {
+		t.Errorf("stats %+v do not match pool stats %+v", stats, upstream)
+	}
+}
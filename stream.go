@@ -0,0 +1,268 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultStreamBlock = 5 * time.Second
+	defaultStreamCount = 10
+)
+
+// StreamMessage is an entry read from a stream
+type StreamMessage struct {
+	Stream string
+	ID     string
+	Values map[string]any
+}
+
+// StreamConsumerConfig configures ConsumeStream
+type StreamConsumerConfig struct {
+	Stream   string
+	Group    string
+	Consumer string        // name of this consumer within the group
+	Block    time.Duration // how long each read waits for new entries, 0 for 5s
+	Count    int64         // entries fetched per read, 0 for 10
+	StartID  string        // where a newly created group starts, "$" (default) for new entries or "0" for the whole stream
+
+	// ClaimMinIdle, when set, reclaims entries pending for at least this
+	// long, such as those of a consumer that died, at start and after each
+	// reconnect
+	ClaimMinIdle time.Duration
+
+	// OnReconnect, when set, is called each time reading works again after
+	// failed reads
+	OnReconnect func(StreamReconnect)
+}
+
+// StreamReconnect reports that ConsumeStream reads again after failed reads
+type StreamReconnect struct {
+	Stream string
+	LastID string // last entry read before the failure, where reading resumed; empty if none
+	Err    error  // the error that interrupted reading
+}
+
+// AddToStream appends an entry with values to stream with XADD and returns
+// its ID
+func (c *Client) AddToStream(ctx context.Context, stream string, values map[string]any) (string, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return "", ErrNilClient
+	}
+	if stream == "" {
+		return "", fmt.Errorf("%w: stream is required", ErrInvalidArgument)
+	}
+	if len(values) == 0 {
+		return "", fmt.Errorf("%w: at least one value is required", ErrInvalidArgument)
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	return st.rdb.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: values}).Result()
+}
+
+// ConsumeStream reads cfg.Stream as cfg.Consumer of cfg.Group, creating
+// the group and stream if missing, and calls handler for each entry. An
+// entry is acknowledged when handler returns nil and otherwise stays
+// pending; entries left pending for this consumer are handled again the
+// next time it starts. Transient read errors are retried with the
+// client's backoff. Once reading works again it resumes after the last
+// entry read, first handling any entries a lost reply had delivered, and
+// reports the reconnect to cfg.OnReconnect. With cfg.ClaimMinIdle set,
+// entries other consumers left pending that long are reclaimed and handled
+// at start and after each reconnect. It blocks until ctx is cancelled or
+// the client shuts down, then returns nil; other errors stop it and are
+// returned.
+func (c *Client) ConsumeStream(ctx context.Context, cfg StreamConsumerConfig, handler func(msg StreamMessage) error) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if cfg.Stream == "" || cfg.Group == "" || cfg.Consumer == "" {
+		return fmt.Errorf("%w: stream, group, and consumer are required", ErrInvalidArgument)
+	}
+	if cfg.Block < 0 || cfg.Count < 0 || cfg.ClaimMinIdle < 0 {
+		return fmt.Errorf("%w: block, count, and claim min idle must not be negative", ErrInvalidArgument)
+	}
+	if handler == nil {
+		return fmt.Errorf("%w: handler is required", ErrInvalidArgument)
+	}
+	if cfg.Block == 0 {
+		cfg.Block = defaultStreamBlock
+	}
+	if cfg.Count == 0 {
+		cfg.Count = defaultStreamCount
+	}
+	if cfg.StartID == "" {
+		cfg.StartID = "$"
+	}
+
+	ctx, cancel := c.backgroundContext(ctx)
+	defer cancel()
+
+	createCtx, cancelCreate := c.writeContext(ctx)
+	err := st.rdb.XGroupCreateMkStream(createCtx, cfg.Stream, cfg.Group, cfg.StartID).Err()
+	cancelCreate()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("create group %q: %w", cfg.Group, err)
+	}
+
+	// Entries still pending for this consumer come first, then new ones.
+	// lastID is the last entry read, where reading resumes after a
+	// reconnect, and claimFrom the XAUTOCLAIM cursor while stale entries
+	// are being reclaimed.
+	id, lastID, attempt := "0", "", 0
+	var claimFrom string
+	if cfg.ClaimMinIdle > 0 {
+		claimFrom = "0-0"
+	}
+	var lost error // the read error a reconnect recovers from
+	for ctx.Err() == nil {
+		var streams []redis.XStream
+		var err error
+		claiming := claimFrom != ""
+		if claiming {
+			var next string
+			if streams, next, err = c.claimStale(ctx, st, cfg, claimFrom); err == nil {
+				claimFrom = next
+			}
+		} else {
+			streams, err = c.readGroup(ctx, st, cfg, id)
+		}
+		if err != nil && !errors.Is(err, redis.Nil) {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// A read outliving its own deadline is retried like a
+			// network error, as ctx itself is still live
+			if !IsRetryable(err) && !errors.Is(err, context.DeadlineExceeded) {
+				return fmt.Errorf("read stream %q: %w", cfg.Stream, err)
+			}
+			if lost == nil {
+				lost = err
+				// Entries a lost reply delivered are pending for this
+				// consumer after lastID, so read those first again
+				if id == ">" {
+					id = lastID
+					if id == "" {
+						id = "0"
+					}
+				}
+				if cfg.ClaimMinIdle > 0 {
+					claimFrom = "0-0"
+				}
+			}
+			if logger := st.config.Logger; logger != nil {
+				logger.Warnf("reading stream %q failed, retrying: %v", cfg.Stream, err)
+			}
+			timer := time.NewTimer(c.nextRetry("ConsumeStream", attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil
+			case <-timer.C:
+			}
+			attempt++
+			continue
+		}
+		attempt = 0
+		if lost != nil {
+			if logger := st.config.Logger; logger != nil {
+				logger.Infof("reading stream %q again, resuming after %q", cfg.Stream, lastID)
+			}
+			if cfg.OnReconnect != nil {
+				cfg.OnReconnect(StreamReconnect{Stream: cfg.Stream, LastID: lastID, Err: lost})
+			}
+			lost = nil
+		}
+
+		var n int
+		var lastRead string
+		for _, stream := range streams {
+			n += len(stream.Messages)
+			for _, msg := range stream.Messages {
+				lastRead = msg.ID
+				if err := handler(StreamMessage{Stream: stream.Stream, ID: msg.ID, Values: msg.Values}); err != nil {
+					if logger := st.config.Logger; logger != nil {
+						logger.Warnf("handling stream entry %s failed, leaving it pending: %v", msg.ID, err)
+					}
+					continue
+				}
+				// A handled entry is acked even if ctx was cancelled meanwhile
+				ackCtx, cancelAck := c.writeContext(context.WithoutCancel(ctx))
+				err := st.rdb.XAck(ackCtx, cfg.Stream, cfg.Group, msg.ID).Err()
+				cancelAck()
+				if err != nil {
+					return fmt.Errorf("ack %s: %w", msg.ID, err)
+				}
+			}
+		}
+		if claiming {
+			// Reclaimed entries are older than the read cursor
+			continue
+		}
+		if lastRead != "" {
+			lastID = lastRead
+		}
+		if id != ">" {
+			// Continue after the pending entries just handled, since
+			// failed ones would otherwise be read again
+			if n < int(cfg.Count) {
+				id = ">"
+			} else {
+				id = lastRead
+			}
+		}
+	}
+	return nil
+}
+
+// readGroup reads cfg.Stream after id with XREADGROUP, blocking for new
+// entries only when id is ">"
+func (c *Client) readGroup(ctx context.Context, st *clientState, cfg StreamConsumerConfig, id string) ([]redis.XStream, error) {
+	args := &redis.XReadGroupArgs{
+		Group:    cfg.Group,
+		Consumer: cfg.Consumer,
+		Streams:  []string{cfg.Stream, id},
+		Count:    cfg.Count,
+		Block:    cfg.Block,
+	}
+	if id != ">" {
+		args.Block = -1 // pending entries never block
+	}
+	ctx, cancel := c.blockingContext(ctx, max(args.Block, 0))
+	defer cancel()
+	return st.rdb.XReadGroup(ctx, args).Result()
+}
+
+// claimStale moves up to cfg.Count entries pending for at least
+// cfg.ClaimMinIdle to cfg.Consumer with XAUTOCLAIM, scanning from start.
+// It returns them with the cursor to continue from, empty once the scan
+// is complete.
+func (c *Client) claimStale(ctx context.Context, st *clientState, cfg StreamConsumerConfig, start string) ([]redis.XStream, string, error) {
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	msgs, next, err := st.rdb.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   cfg.Stream,
+		Group:    cfg.Group,
+		Consumer: cfg.Consumer,
+		MinIdle:  cfg.ClaimMinIdle,
+		Start:    start,
+		Count:    cfg.Count,
+	}).Result()
+	if err != nil {
+		return nil, start, err
+	}
+	if next == "0-0" {
+		next = ""
+	}
+	return []redis.XStream{{Stream: cfg.Stream, Messages: msgs}}, next, nil
+}
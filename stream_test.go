@@ -0,0 +1,295 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestAddToStream tests appending stream entries
+func TestAddToStream(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.AddToStream(context.Background(), "s", map[string]any{"a": 1}); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.AddToStream(context.Background(), "", map[string]any{"a": 1}); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("empty stream: expected ErrInvalidArgument, got %v", err)
+		}
+		if _, err := client.AddToStream(context.Background(), "s", nil); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("no values: expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	stream := "rediskit:test:stream:add"
+	defer client.Del(ctx, stream)
+
+	id, err := client.AddToStream(ctx, stream, map[string]any{"event": "created"})
+	if err != nil {
+		t.Fatalf("AddToStream: %v", err)
+	}
+	entries := client.XRange(ctx, stream, "-", "+").Val()
+	if len(entries) != 1 || entries[0].ID != id || entries[0].Values["event"] != "created" {
+		t.Errorf("unexpected entries %+v", entries)
+	}
+}
+
+// TestConsumeStream tests consuming a stream through a consumer group
+func TestConsumeStream(t *testing.T) {
+	handler := func(StreamMessage) error { return nil }
+
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.ConsumeStream(context.Background(), StreamConsumerConfig{}, handler); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		valid := StreamConsumerConfig{Stream: "s", Group: "g", Consumer: "c"}
+		tests := []struct {
+			name    string
+			cfg     StreamConsumerConfig
+			handler func(StreamMessage) error
+		}{
+			{name: "no stream", cfg: StreamConsumerConfig{Group: "g", Consumer: "c"}, handler: handler},
+			{name: "no group", cfg: StreamConsumerConfig{Stream: "s", Consumer: "c"}, handler: handler},
+			{name: "no consumer", cfg: StreamConsumerConfig{Stream: "s", Group: "g"}, handler: handler},
+			{name: "negative block", cfg: StreamConsumerConfig{Stream: "s", Group: "g", Consumer: "c", Block: -1}, handler: handler},
+			{name: "negative claim min idle", cfg: StreamConsumerConfig{Stream: "s", Group: "g", Consumer: "c", ClaimMinIdle: -1}, handler: handler},
+			{name: "nil handler", cfg: valid},
+		}
+		for _, tt := range tests {
+			if err := client.ConsumeStream(context.Background(), tt.cfg, tt.handler); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("%s: expected ErrInvalidArgument, got %v", tt.name, err)
+			}
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	stream := "rediskit:test:stream:consume"
+	defer client.Del(ctx, stream)
+	cfg := StreamConsumerConfig{
+		Stream:   stream,
+		Group:    "workers",
+		Consumer: "worker-1",
+		Block:    50 * time.Millisecond,
+		Count:    2,
+		StartID:  "0",
+	}
+
+	// consume runs ConsumeStream until want entries were handled
+	consume := func(t *testing.T, want int, fail func(StreamMessage) bool) []string {
+		t.Helper()
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var mu sync.Mutex
+		var handled []string
+		done := make(chan error, 1)
+		go func() {
+			done <- client.ConsumeStream(ctx, cfg, func(msg StreamMessage) error {
+				mu.Lock()
+				defer mu.Unlock()
+				handled = append(handled, msg.Values["n"].(string))
+				if len(handled) == want {
+					cancel()
+				}
+				if fail(msg) {
+					return errors.New("handler failed")
+				}
+				return nil
+			})
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("ConsumeStream: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for entries")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return handled
+	}
+
+	for i := 1; i <= 5; i++ {
+		if _, err := client.AddToStream(ctx, stream, map[string]any{"n": fmt.Sprint(i)}); err != nil {
+			t.Fatalf("AddToStream: %v", err)
+		}
+	}
+
+	t.Run("acks on success and leaves failures pending", func(t *testing.T) {
+		handled := consume(t, 5, func(msg StreamMessage) bool { return msg.Values["n"] == "3" })
+		if len(handled) != 5 {
+			t.Fatalf("expected 5 entries handled, got %v", handled)
+		}
+		pending := client.XPending(ctx, stream, cfg.Group).Val()
+		if pending.Count != 1 {
+			t.Errorf("expected 1 pending entry, got %d", pending.Count)
+		}
+	})
+
+	t.Run("existing group and pending entries are retried", func(t *testing.T) {
+		handled := consume(t, 1, func(StreamMessage) bool { return false })
+		if len(handled) != 1 || handled[0] != "3" {
+			t.Errorf("expected the failed entry to be retried, got %v", handled)
+		}
+		if pending := client.XPending(ctx, stream, cfg.Group).Val(); pending.Count != 0 {
+			t.Errorf("expected no pending entries, got %d", pending.Count)
+		}
+	})
+}
+
+// TestConsumeStreamReconnect tests that ConsumeStream resumes after failed
+// reads without losing entries
+func TestConsumeStreamReconnect(t *testing.T) {
+	base := newTestClient(t)
+	ctx := context.Background()
+
+	// run consumes cfg.Stream with client until want entries were handled
+	run := func(t *testing.T, client *Client, cfg StreamConsumerConfig, want int) []string {
+		t.Helper()
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var mu sync.Mutex
+		var handled []string
+		done := make(chan error, 1)
+		go func() {
+			done <- client.ConsumeStream(ctx, cfg, func(msg StreamMessage) error {
+				mu.Lock()
+				defer mu.Unlock()
+				handled = append(handled, msg.Values["n"].(string))
+				if len(handled) == want {
+					cancel()
+				}
+				return nil
+			})
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("ConsumeStream: %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for entries")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return handled
+	}
+
+	t.Run("entries of a lost reply are read again", func(t *testing.T) {
+		stream := "rediskit:test:stream:lost"
+		defer base.Del(ctx, stream)
+		cfg := *base.GetConfig()
+		cfg.MinRetryBackoff = time.Millisecond
+		client, err := NewClient(&cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		lost := &lostReply{}
+		client.AddHook(lost)
+
+		var events []StreamReconnect
+		consumer := StreamConsumerConfig{
+			Stream:      stream,
+			Group:       "workers",
+			Consumer:    "worker-1",
+			Block:       50 * time.Millisecond,
+			StartID:     "0",
+			OnReconnect: func(e StreamReconnect) { events = append(events, e) },
+		}
+		for i := 1; i <= 2; i++ {
+			client.AddToStream(ctx, stream, map[string]any{"n": fmt.Sprint(i)})
+		}
+		lost.armed.Store(true)
+
+		handled := run(t, client, consumer, 2)
+		if fmt.Sprint(handled) != "[1 2]" {
+			t.Errorf("expected both entries handled once, got %v", handled)
+		}
+		if len(events) != 1 || events[0].Stream != stream || !errors.Is(events[0].Err, io.ErrUnexpectedEOF) {
+			t.Errorf("expected one reconnect event, got %+v", events)
+		}
+		if pending := client.XPending(ctx, stream, consumer.Group).Val(); pending.Count != 0 {
+			t.Errorf("expected no pending entries, got %d", pending.Count)
+		}
+	})
+
+	t.Run("stale entries are reclaimed", func(t *testing.T) {
+		stream := "rediskit:test:stream:claim"
+		defer base.Del(ctx, stream)
+		base.XGroupCreateMkStream(ctx, stream, "workers", "0")
+		base.AddToStream(ctx, stream, map[string]any{"n": "1"})
+		// A consumer that reads the entry and dies before acking it
+		base.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "workers", Consumer: "dead", Streams: []string{stream, ">"}})
+		time.Sleep(20 * time.Millisecond)
+
+		consumer := StreamConsumerConfig{
+			Stream:       stream,
+			Group:        "workers",
+			Consumer:     "worker-1",
+			Block:        50 * time.Millisecond,
+			ClaimMinIdle: 10 * time.Millisecond,
+		}
+		if handled := run(t, base, consumer, 1); fmt.Sprint(handled) != "[1]" {
+			t.Errorf("expected the stale entry to be handled, got %v", handled)
+		}
+		if pending := base.XPending(ctx, stream, consumer.Group).Val(); pending.Count != 0 {
+			t.Errorf("expected no pending entries, got %d", pending.Count)
+		}
+	})
+}
+
+// lostReply fails the first XREADGROUP for new entries after it is armed
+// once the server has delivered them, as a dropped connection would
+type lostReply struct {
+	armed atomic.Bool
+}
+
+func (h *lostReply) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *lostReply) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		read, ok := cmd.(*redis.XStreamSliceCmd)
+		if ok && err == nil && len(read.Val()) > 0 && len(read.Val()[0].Messages) > 0 && h.armed.CompareAndSwap(true, false) {
+			cmd.SetErr(io.ErrUnexpectedEOF)
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+}
+
+func (h *lostReply) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
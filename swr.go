@@ -0,0 +1,100 @@
+package rediskit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// swrEntry is the stored form of a stale-while-revalidate value
+type swrEntry[T any] struct {
+	Value      T     `json:"v"`
+	FreshUntil int64 `json:"f"` // unix milliseconds
+}
+
+// GetStaleWhileRevalidate returns the value cached at key, loading it with
+// loader on a hard miss. A value older than freshTTL but younger than
+// staleTTL is returned immediately while a background refresh runs; only one
+// refresh per key runs at a time in this process. staleTTL is the total
+// lifetime of the cached entry and should be at least freshTTL.
+func GetStaleWhileRevalidate[T any](ctx context.Context, c *Client, key string, freshTTL, staleTTL time.Duration, loader func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	st := c.load()
+	if st.rdb == nil {
+		return zero, ErrNilClient
+	}
+
+	readCtx, cancel := c.readContext(ctx)
+	data, err := st.rdb.Get(readCtx, key).Bytes()
+	cancel()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return zero, err
+	}
+	if err == nil {
+		var entry swrEntry[T]
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return zero, fmt.Errorf("decode %q: %w", key, err)
+		}
+		if c.now(ctx).UnixMilli() >= entry.FreshUntil {
+			c.revalidate(ctx, key, func(ctx context.Context) error {
+				_, err := loadSWR(ctx, c, key, freshTTL, staleTTL, loader)
+				return err
+			})
+		}
+		return entry.Value, nil
+	}
+
+	return loadSWR(ctx, c, key, freshTTL, staleTTL, loader)
+}
+
+// loadSWR calls loader and stores its result at key
+func loadSWR[T any](ctx context.Context, c *Client, key string, freshTTL, staleTTL time.Duration, loader func(ctx context.Context) (T, error)) (T, error) {
+	v, err := loader(ctx)
+	if err != nil {
+		return v, err
+	}
+	data, err := json.Marshal(swrEntry[T]{
+		Value:      v,
+		FreshUntil: c.now(ctx).Add(freshTTL).UnixMilli(),
+	})
+	if err != nil {
+		return v, fmt.Errorf("encode %q: %w", key, err)
+	}
+	// The loader is not bound by the write timeout, only the write itself
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	return v, c.load().rdb.Set(ctx, key, data, staleTTL).Err()
+}
+
+// revalidate runs refresh in the background unless a refresh for key is
+// already in flight. The refresh is detached from the caller's
+// cancellation but bounded by DefaultTimeout, and counts as in flight for
+// Shutdown; it is skipped once the client is shutting down. Failures are
+// logged, since there is no caller left to return them to.
+func (c *Client) revalidate(ctx context.Context, key string, refresh func(ctx context.Context) error) {
+	if !c.inflight.acquire() {
+		return
+	}
+	if _, running := c.refreshing.LoadOrStore(key, struct{}{}); running {
+		c.inflight.release()
+		return
+	}
+	go func() {
+		defer c.inflight.release()
+		defer c.refreshing.Delete(key)
+		// The refresh is already counted, so its commands must not be
+		// refused while Shutdown waits for it
+		ctx := context.WithValue(context.WithoutCancel(ctx), inflightKey{}, true)
+		ctx, cancel := c.ctxWithTimeout(ctx)
+		defer cancel()
+		if err := refresh(ctx); err != nil {
+			if logger := c.load().config.Logger; logger != nil {
+				logger.Warnf("revalidating %q failed: %v", key, err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,160 @@
+package rediskit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestGetStaleWhileRevalidate tests fresh, stale, and missing lookups
+func TestGetStaleWhileRevalidate(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		_, err := GetStaleWhileRevalidate(context.Background(), client, "k", time.Second, time.Minute,
+			func(ctx context.Context) (int, error) { return 1, nil })
+		if err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	t.Run("hard miss loads synchronously", func(t *testing.T) {
+		key := "rediskit:test:swr:miss"
+		defer client.Del(ctx, key)
+
+		var calls int32
+		loader := func(ctx context.Context) (string, error) {
+			atomic.AddInt32(&calls, 1)
+			return "loaded", nil
+		}
+
+		got, err := GetStaleWhileRevalidate(ctx, client, key, time.Minute, time.Hour, loader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "loaded" {
+			t.Errorf("got %q, want %q", got, "loaded")
+		}
+
+		got, err = GetStaleWhileRevalidate(ctx, client, key, time.Minute, time.Hour, loader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "loaded" {
+			t.Errorf("got %q, want %q", got, "loaded")
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Errorf("loader called %d times, want 1", n)
+		}
+	})
+
+	t.Run("stale value is served and refreshed once", func(t *testing.T) {
+		key := "rediskit:test:swr:stale"
+		defer client.Del(ctx, key)
+
+		var version int32
+		release := make(chan struct{})
+		loader := func(ctx context.Context) (int32, error) {
+			v := atomic.AddInt32(&version, 1)
+			if v > 1 {
+				<-release
+			}
+			return v, nil
+		}
+
+		if _, err := GetStaleWhileRevalidate(ctx, client, key, time.Millisecond, time.Hour, loader); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				got, err := GetStaleWhileRevalidate(ctx, client, key, time.Millisecond, time.Hour, loader)
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if got != 1 {
+					t.Errorf("expected stale value 1, got %d", got)
+				}
+			}()
+		}
+		wg.Wait()
+		close(release)
+
+		deadline := time.Now().Add(time.Second)
+		for {
+			var entry swrEntry[int32]
+			data, err := client.Get(ctx, key).Bytes()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := json.Unmarshal(data, &entry); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if entry.Value == 2 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("refreshed value never appeared, got %d", entry.Value)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		if n := atomic.LoadInt32(&version); n != 2 {
+			t.Errorf("loader called %d times, want 2", n)
+		}
+	})
+
+	t.Run("shutdown waits for a failing refresh", func(t *testing.T) {
+		key := "rediskit:test:swr:shutdown"
+		defer client.Del(ctx, key)
+
+		logger := &recordingLogger{}
+		cfg := DefaultConfig()
+		cfg.Logger = logger
+		own, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+
+		var finished int32
+		loader := func(ctx context.Context) (int, error) {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("expected the refresh to have a deadline")
+			}
+			time.Sleep(50 * time.Millisecond)
+			atomic.StoreInt32(&finished, 1)
+			return 0, errors.New("backend down")
+		}
+		if _, err := loadSWR(ctx, own, key, time.Millisecond, time.Hour,
+			func(context.Context) (int, error) { return 1, nil }); err != nil {
+			t.Fatalf("loadSWR: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if got, err := GetStaleWhileRevalidate(ctx, own, key, time.Millisecond, time.Hour, loader); err != nil || got != 1 {
+			t.Fatalf("expected stale value 1, got %d, %v", got, err)
+		}
+		shutdownCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+		if err := own.Shutdown(shutdownCtx); err != nil {
+			t.Fatalf("Shutdown: %v", err)
+		}
+		if atomic.LoadInt32(&finished) != 1 {
+			t.Error("expected Shutdown to wait for the refresh")
+		}
+		lines := logger.lines()
+		if len(lines) == 0 || !strings.Contains(lines[len(lines)-1], "backend down") {
+			t.Errorf("expected the refresh failure to be logged, got %q", lines)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package rediskit
+
+import (
+	"context"
+	"time"
+)
+
+// WithDefaultTimeout returns ctx bounded by DefaultTimeout, or ctx itself
+// when it already has a deadline, so commands composed by callers time out
+// the same way as this package's helpers. The cancel func must be called
+// either way.
+func (c *Client) WithDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return c.ctxWithTimeout(ctx)
+}
+
+// ctxWithTimeout applies DefaultTimeout to ctx unless it has a deadline
+func (c *Client) ctxWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	st := c.load()
+	return withTimeout(ctx, st.config.DefaultTimeout)
+}
+
+// readContext applies ReadTimeout, or DefaultTimeout when it is zero, to
+// ctx unless it has a deadline
+func (c *Client) readContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	st := c.load()
+	if st.config.ReadTimeout > 0 {
+		return withTimeout(ctx, st.config.ReadTimeout)
+	}
+	return c.ctxWithTimeout(ctx)
+}
+
+// writeContext applies WriteTimeout, or DefaultTimeout when it is zero, to
+// ctx unless it has a deadline
+func (c *Client) writeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	st := c.load()
+	if st.config.WriteTimeout > 0 {
+		return withTimeout(ctx, st.config.WriteTimeout)
+	}
+	return c.ctxWithTimeout(ctx)
+}
+
+// blockingContext is readContext for a command that blocks on the server
+// for up to block, so the timeout only starts once the block has passed
+func (c *Client) blockingContext(ctx context.Context, block time.Duration) (context.Context, context.CancelFunc) {
+	st := c.load()
+	timeout := st.config.ReadTimeout
+	if timeout == 0 {
+		timeout = st.config.DefaultTimeout
+	}
+	return withTimeout(ctx, block+timeout)
+}
+
+// withTimeout bounds ctx by timeout unless it already has a deadline
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
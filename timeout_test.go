@@ -0,0 +1,251 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestWithDefaultTimeout tests applying DefaultTimeout to contexts
+func TestWithDefaultTimeout(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DefaultTimeout = 2 * time.Second
+	client := clientWith(nil, cfg)
+
+	t.Run("no deadline gets default timeout", func(t *testing.T) {
+		start := time.Now()
+		ctx, cancel := client.WithDefaultTimeout(context.Background())
+		defer cancel()
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			t.Fatal("expected a deadline")
+		}
+		if d := deadline.Sub(start); d < cfg.DefaultTimeout || d > cfg.DefaultTimeout+time.Second {
+			t.Errorf("expected a deadline about %v away, got %v", cfg.DefaultTimeout, d)
+		}
+		cancel()
+		if ctx.Err() == nil {
+			t.Error("expected cancel to cancel the context")
+		}
+	})
+
+	t.Run("existing deadline is kept", func(t *testing.T) {
+		parent, parentCancel := context.WithTimeout(context.Background(), time.Hour)
+		defer parentCancel()
+		ctx, cancel := client.WithDefaultTimeout(parent)
+		if ctx != parent {
+			t.Error("expected the original context to be returned")
+		}
+		cancel()
+		if ctx.Err() != nil {
+			t.Error("expected cancel not to cancel the caller's context")
+		}
+	})
+}
+
+// TestOperationTimeouts tests choosing the read or write timeout for helpers
+func TestOperationTimeouts(t *testing.T) {
+	tests := []struct {
+		name      string
+		read      time.Duration
+		write     time.Duration
+		wantRead  time.Duration
+		wantWrite time.Duration
+	}{
+		{name: "both fall back to default", wantRead: 5 * time.Second, wantWrite: 5 * time.Second},
+		{name: "read only", read: time.Second, wantRead: time.Second, wantWrite: 5 * time.Second},
+		{name: "write only", write: 3 * time.Second, wantRead: 5 * time.Second, wantWrite: 3 * time.Second},
+		{name: "both set", read: time.Second, write: 3 * time.Second, wantRead: time.Second, wantWrite: 3 * time.Second},
+	}
+
+	remaining := func(t *testing.T, ctx context.Context) time.Duration {
+		t.Helper()
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			t.Fatal("expected a deadline")
+		}
+		return time.Until(deadline)
+	}
+	near := func(got, want time.Duration) bool {
+		return got <= want && got > want-time.Second
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.DefaultTimeout = 5 * time.Second
+			cfg.ReadTimeout = tt.read
+			cfg.WriteTimeout = tt.write
+			client := clientWith(nil, cfg)
+
+			ctx, cancel := client.readContext(context.Background())
+			defer cancel()
+			if got := remaining(t, ctx); !near(got, tt.wantRead) {
+				t.Errorf("read: expected about %v, got %v", tt.wantRead, got)
+			}
+			ctx, cancel = client.writeContext(context.Background())
+			defer cancel()
+			if got := remaining(t, ctx); !near(got, tt.wantWrite) {
+				t.Errorf("write: expected about %v, got %v", tt.wantWrite, got)
+			}
+		})
+	}
+
+	t.Run("negative timeout is rejected", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ReadTimeout = -time.Second
+		if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	t.Run("GetJSON uses the read timeout", func(t *testing.T) {
+		newTestClient(t) // skips without Redis
+		cfg := DefaultConfig()
+		cfg.DefaultTimeout = time.Minute
+		cfg.ReadTimeout = 2 * time.Second
+		cfg.WriteTimeout = 30 * time.Second
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		var got time.Duration
+		client.AddHook(deadlineHook(func(d time.Duration) { got = d }))
+
+		var v int
+		if err := client.GetJSON(context.Background(), "rediskit:test:timeout:missing", &v); !errors.Is(err, ErrCacheMiss) {
+			t.Fatalf("GetJSON: %v", err)
+		}
+		if !near(got, cfg.ReadTimeout) {
+			t.Errorf("expected the command deadline about %v away, got %v", cfg.ReadTimeout, got)
+		}
+	})
+
+	t.Run("bulk JSON helpers use the read and write timeouts", func(t *testing.T) {
+		newTestClient(t) // skips without Redis
+		cfg := DefaultConfig()
+		cfg.DefaultTimeout = time.Minute
+		cfg.ReadTimeout = 2 * time.Second
+		cfg.WriteTimeout = 30 * time.Second
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		var got time.Duration
+		client.AddHook(deadlineHook(func(d time.Duration) { got = d }))
+		ctx := context.Background()
+		key := "rediskit:test:timeout:bulk"
+		defer client.Del(ctx, key)
+
+		if err := client.MSetJSON(ctx, map[string]any{key: 1}, time.Minute); err != nil {
+			t.Fatalf("MSetJSON: %v", err)
+		}
+		if !near(got, cfg.WriteTimeout) {
+			t.Errorf("MSetJSON: expected the deadline about %v away, got %v", cfg.WriteTimeout, got)
+		}
+
+		var vs []int
+		if _, err := client.MGetJSON(ctx, []string{key}, &vs); err != nil {
+			t.Fatalf("MGetJSON: %v", err)
+		}
+		if !near(got, cfg.ReadTimeout) {
+			t.Errorf("MGetJSON: expected the deadline about %v away, got %v", cfg.ReadTimeout, got)
+		}
+	})
+
+	t.Run("data structure helpers use the read and write timeouts", func(t *testing.T) {
+		newTestClient(t) // skips without Redis
+		cfg := DefaultConfig()
+		cfg.DefaultTimeout = time.Minute
+		cfg.ReadTimeout = 2 * time.Second
+		cfg.WriteTimeout = 30 * time.Second
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		var got time.Duration
+		client.AddHook(deadlineHook(func(d time.Duration) { got = d }))
+		ctx := context.Background()
+		key := "rediskit:test:timeout:ds"
+		defer client.Del(ctx, key, key+":list", key+":zset", key+":stream", key+":rate")
+
+		tests := []struct {
+			name string
+			call func() error
+			want time.Duration
+		}{
+			{"PFAddItems", func() error { return client.PFAddItems(ctx, key, "a") }, cfg.WriteTimeout},
+			{"PFCount", func() error { _, err := client.PFCount(ctx, key); return err }, cfg.ReadTimeout},
+			{"PushJob", func() error { return client.PushJob(ctx, key+":list", 1) }, cfg.WriteTimeout},
+			{"ListRange", func() error { _, err := client.ListRange(ctx, key+":list", 0, -1); return err }, cfg.ReadTimeout},
+			{"PopN", func() error { _, err := client.PopN(ctx, key+":list", 1); return err }, cfg.WriteTimeout},
+			{"PopJob blocks for its wait first", func() error {
+				var v int
+				if err := client.PopJob(ctx, key+":list", time.Second, &v); !errors.Is(err, ErrQueueEmpty) {
+					return err
+				}
+				return nil
+			}, time.Second + cfg.ReadTimeout},
+			{"LeaderboardAdd", func() error { return client.LeaderboardAdd(ctx, key+":zset", "m", 1) }, cfg.WriteTimeout},
+			{"LeaderboardTop", func() error { _, err := client.LeaderboardTop(ctx, key+":zset", 1); return err }, cfg.ReadTimeout},
+			{"LeaderboardRank", func() error { _, _, err := client.LeaderboardRank(ctx, key+":zset", "m"); return err }, cfg.ReadTimeout},
+			{"AllowN", func() error { _, _, err := client.AllowN(ctx, key+":rate", 10, time.Minute, 1); return err }, cfg.WriteTimeout},
+			{"ZMove", func() error { _, err := client.ZMove(ctx, key+":zset", key+":zset", "m", 1); return err }, cfg.WriteTimeout},
+			{"AddToStream", func() error {
+				_, err := client.AddToStream(ctx, key+":stream", map[string]any{"f": "v"})
+				return err
+			}, cfg.WriteTimeout},
+			{"Lock", func() error {
+				lock, err := client.Lock(ctx, key+":lock", time.Minute)
+				if err != nil {
+					return err
+				}
+				return lock.Unlock(ctx)
+			}, cfg.WriteTimeout},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got = 0
+				if err := tt.call(); err != nil {
+					t.Fatalf("%s: %v", tt.name, err)
+				}
+				if !near(got, tt.want) {
+					t.Errorf("expected the command deadline about %v away, got %v", tt.want, got)
+				}
+			})
+		}
+	})
+}
+
+// deadlineHook reports the time left before the context deadline of each
+// command and pipeline
+type deadlineHook func(time.Duration)
+
+func (h deadlineHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h deadlineHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if deadline, ok := ctx.Deadline(); ok {
+			h(time.Until(deadline))
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (h deadlineHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if deadline, ok := ctx.Deadline(); ok {
+			h(time.Until(deadline))
+		}
+		return next(ctx, cmds)
+	}
+}
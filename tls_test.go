@@ -0,0 +1,88 @@
+package rediskit
+
+import (
+	"crypto/tls"
+	"errors"
+	"testing"
+)
+
+// TestNewClientTLS tests that TLS settings reach the underlying options
+func TestNewClientTLS(t *testing.T) {
+	custom := &tls.Config{ServerName: "custom-name", MinVersion: tls.VersionTLS13}
+
+	tests := []struct {
+		name           string
+		enableTLS      bool
+		serverName     string
+		tlsConfig      *tls.Config
+		wantTLS        bool
+		wantServerName string
+	}{
+		{name: "disabled"},
+		{name: "enabled uses host", enableTLS: true, wantTLS: true, wantServerName: "secure-host"},
+		{name: "enabled with server name", enableTLS: true, serverName: "sni-name", wantTLS: true, wantServerName: "sni-name"},
+		{name: "explicit config", tlsConfig: custom, wantTLS: true, wantServerName: "custom-name"},
+		{name: "explicit config with matching name", enableTLS: true, serverName: "custom-name", tlsConfig: custom, wantTLS: true, wantServerName: "custom-name"},
+		{name: "explicit config without name", serverName: "sni-name", tlsConfig: &tls.Config{}, wantTLS: true, wantServerName: "sni-name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Host = "secure-host"
+			cfg.EnableTLS = tt.enableTLS
+			cfg.TLSServerName = tt.serverName
+			cfg.TLSConfig = tt.tlsConfig
+
+			client, err := NewClient(cfg)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			defer client.Close()
+
+			got := client.Options().TLSConfig
+			if !tt.wantTLS {
+				if got != nil {
+					t.Errorf("expected no TLS config, got %+v", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("expected TLS config on the underlying client")
+			}
+			if got.ServerName != tt.wantServerName {
+				t.Errorf("expected server name %q, got %q", tt.wantServerName, got.ServerName)
+			}
+			if tt.tlsConfig == custom && got.MinVersion != tls.VersionTLS13 {
+				t.Error("expected the explicit TLS config to be used")
+			}
+		})
+	}
+
+	t.Run("explicit config is not mutated", func(t *testing.T) {
+		base := &tls.Config{}
+		cfg := DefaultConfig()
+		cfg.TLSServerName = "sni-name"
+		cfg.TLSConfig = base
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if base.ServerName != "" {
+			t.Errorf("caller's TLS config was mutated: %q", base.ServerName)
+		}
+	})
+}
+
+// TestValidateTLS tests rejection of conflicting TLS server names
+func TestValidateTLS(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.EnableTLS = true
+	cfg.TLSServerName = "one"
+	cfg.TLSConfig = &tls.Config{ServerName: "two"}
+
+	if err := cfg.Validate(); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
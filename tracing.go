@@ -0,0 +1,75 @@
+package rediskit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentTracing installs the OpenTelemetry tracing hook on rdb when
+// EnableTracing is set. Spans are named after the command; the full
+// command, keys included, is only recorded when TraceStatements is set.
+func (c *Config) instrumentTracing(rdb redis.UniversalClient) error {
+	if !c.EnableTracing {
+		return nil
+	}
+	opts := []redisotel.TracingOption{redisotel.WithDBStatement(c.TraceStatements)}
+	if c.TracerProvider != nil {
+		opts = append(opts, redisotel.WithTracerProvider(c.TracerProvider))
+	}
+	if c.KeyPrefix != "" {
+		opts = append(opts, redisotel.WithAttributes(attribute.String("db.redis.key_prefix", c.KeyPrefix)))
+	}
+	return redisotel.InstrumentTracing(rdb, opts...)
+}
+
+// cacheSpan returns the span in ctx that GetJSON and SetJSON add cache
+// events to, or nil when EnableTracing is off or the span is not recording
+func (c *Client) cacheSpan(ctx context.Context) trace.Span {
+	if !c.load().config.EnableTracing {
+		return nil
+	}
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return nil
+	}
+	return span
+}
+
+// getWithTTL reads key, and when span is set its remaining TTL in the same
+// round trip so that a cache.hit event can carry it. The TTL is negative
+// when the key has no expiry or was not asked for.
+func getWithTTL(ctx context.Context, rdb *redis.Client, key string, span trace.Span) ([]byte, time.Duration, error) {
+	if span == nil {
+		data, err := rdb.Get(ctx, key).Bytes()
+		return data, -1, err
+	}
+	pipe := rdb.Pipeline()
+	get := pipe.Get(ctx, key)
+	pttl := pipe.PTTL(ctx, key)
+	pipe.Exec(ctx)
+	data, err := get.Bytes()
+	return data, pttl.Val(), err
+}
+
+// addCacheEvent adds the cache event name to span, if any. The key is
+// recorded as a truncated SHA-256 hash, never as is, so that keys holding
+// personal data do not leak into traces; the TTL is recorded in
+// milliseconds unless it is negative or zero, meaning no expiry.
+func addCacheEvent(span trace.Span, name, key string, ttl time.Duration) {
+	if span == nil {
+		return
+	}
+	sum := sha256.Sum256([]byte(key))
+	attrs := []attribute.KeyValue{attribute.String("cache.key_hash", hex.EncodeToString(sum[:8]))}
+	if ttl > 0 {
+		attrs = append(attrs, attribute.Int64("cache.ttl_ms", ttl.Milliseconds()))
+	}
+	span.AddEvent(name, trace.WithAttributes(attrs...))
+}
@@ -0,0 +1,157 @@
+package rediskit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestTracing tests that commands are traced when EnableTracing is set
+func TestTracing(t *testing.T) {
+	newTestClient(t) // skips when Redis is unavailable
+
+	tests := []struct {
+		name       string
+		enable     bool
+		statements bool
+		wantSpans  bool
+	}{
+		{name: "disabled", enable: false},
+		{name: "enabled", enable: true, wantSpans: true},
+		{name: "enabled with statements", enable: true, statements: true, wantSpans: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			defer provider.Shutdown(context.Background())
+
+			cfg := DefaultConfig()
+			cfg.KeyPrefix = "rediskit:test:"
+			cfg.EnableTracing = tt.enable
+			cfg.TraceStatements = tt.statements
+			cfg.TracerProvider = provider
+			client, err := NewClient(cfg)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			defer client.Close()
+
+			key := "rediskit:test:tracing:secret-id"
+			client.Get(context.Background(), key)
+
+			var span *tracetest.SpanStub
+			for _, s := range exporter.GetSpans() {
+				if s.Name == "get" {
+					span = &s
+				}
+			}
+			if !tt.wantSpans {
+				if span != nil {
+					t.Error("expected no spans with tracing disabled")
+				}
+				return
+			}
+			if span == nil {
+				t.Fatalf("expected a span named %q", "get")
+			}
+
+			attrs := make(map[string]string)
+			for _, kv := range span.Attributes {
+				attrs[string(kv.Key)] = kv.Value.Emit()
+			}
+			if attrs["db.redis.key_prefix"] != cfg.KeyPrefix {
+				t.Errorf("expected key prefix attribute %q, got %q", cfg.KeyPrefix, attrs["db.redis.key_prefix"])
+			}
+			stmt, ok := attrs["db.statement"]
+			if tt.statements && stmt != "get "+key {
+				t.Errorf("expected the statement to be recorded, got %q", stmt)
+			}
+			if !tt.statements && ok {
+				t.Errorf("expected no statement attribute, got %q", stmt)
+			}
+		})
+	}
+}
+
+// TestTracingCacheEvents tests the cache events GetJSON and SetJSON add to
+// the caller's span
+func TestTracingCacheEvents(t *testing.T) {
+	newTestClient(t) // skips when Redis is unavailable
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer provider.Shutdown(context.Background())
+
+	cfg := DefaultConfig()
+	cfg.EnableTracing = true
+	cfg.TracerProvider = provider
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+
+	key := "rediskit:test:tracing:user:alice@example.com"
+	missing := "rediskit:test:tracing:missing"
+	defer client.Del(context.Background(), key)
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "request")
+	if err := client.SetJSON(ctx, key, "v", time.Minute); err != nil {
+		t.Fatalf("SetJSON: %v", err)
+	}
+	var v string
+	if err := client.GetJSON(ctx, key, &v); err != nil {
+		t.Fatalf("GetJSON: %v", err)
+	}
+	if err := client.GetJSON(ctx, missing, &v); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("GetJSON: expected ErrCacheMiss, got %v", err)
+	}
+	span.End()
+
+	var events []sdktrace.Event
+	for _, s := range exporter.GetSpans() {
+		if s.Name == "request" {
+			events = s.Events
+		}
+	}
+	want := []struct {
+		name    string
+		key     string
+		withTTL bool
+	}{
+		{name: "cache.set", key: key, withTTL: true},
+		{name: "cache.hit", key: key, withTTL: true},
+		{name: "cache.miss", key: missing},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(events))
+	}
+	for i, w := range want {
+		attrs := make(map[string]string)
+		for _, kv := range events[i].Attributes {
+			attrs[string(kv.Key)] = kv.Value.Emit()
+			if strings.Contains(kv.Value.Emit(), "alice") {
+				t.Errorf("%s: raw key leaked into %s", w.name, kv.Key)
+			}
+		}
+		if events[i].Name != w.name {
+			t.Errorf("event %d: expected %s, got %s", i, w.name, events[i].Name)
+		}
+		sum := sha256.Sum256([]byte(w.key))
+		if got := attrs["cache.key_hash"]; got != hex.EncodeToString(sum[:8]) {
+			t.Errorf("%s: unexpected key hash %q", w.name, got)
+		}
+		if _, ok := attrs["cache.ttl_ms"]; ok != w.withTTL {
+			t.Errorf("%s: expected ttl attribute %v, got %v", w.name, w.withTTL, attrs)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package rediskit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NoExpiry is the TTL reported for a key that exists without an expiry
+const NoExpiry time.Duration = -1
+
+// KeyState describes whether a key exists and how long it has left to live
+type KeyState struct {
+	Exists bool
+	TTL    time.Duration // Remaining TTL, NoExpiry for persistent keys, 0 for missing keys
+}
+
+// expireIfPersistentScript sets the TTL in ARGV[1] (milliseconds) on each
+// key in KEYS that exists without an expiry, returning how many it updated
+var expireIfPersistentScript = redis.NewScript(`
+local n = 0
+for _, key in ipairs(KEYS) do
+	if redis.call('PTTL', key) == -1 then
+		redis.call('PEXPIRE', key, ARGV[1])
+		n = n + 1
+	end
+end
+return n
+`)
+
+// ExpireIfPersistent sets ttl on those keys that currently have no expiry,
+// leaving keys with an existing TTL and missing keys untouched, and returns
+// how many keys it updated. It uses EXPIRE ... NX on Redis 7+ and a Lua
+// script checking PTTL on older servers.
+func (c *Client) ExpireIfPersistent(ctx context.Context, ttl time.Duration, keys ...string) (int, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return 0, ErrNilClient
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("%w: ttl must be greater than 0", ErrInvalidArgument)
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	pipe := st.rdb.Pipeline()
+	expires := make([]*redis.BoolCmd, len(keys))
+	for i, key := range keys {
+		expires[i] = pipe.ExpireNX(ctx, key, ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	if isUnsupportedExpireNX(err) {
+		return expireIfPersistentScript.Run(ctx, st.rdb, keys, ttl.Milliseconds()).Int()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, cmd := range expires {
+		if cmd.Val() {
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+// ExpireAll sets ttl on every key in keys with one pipeline of EXPIRE
+// calls. Keys that do not exist are left missing.
+func (c *Client) ExpireAll(ctx context.Context, ttl time.Duration, keys ...string) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("%w: ttl must be greater than 0", ErrInvalidArgument)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pipe := st.rdb.Pipeline()
+	for _, key := range keys {
+		pipe.Expire(ctx, key, ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// TTLRemaining returns how long key has left to live. It returns NoExpiry
+// for a key without an expiry and ErrCacheMiss for a missing key, rather
+// than the negative values PTTL uses for both.
+func (c *Client) TTLRemaining(ctx context.Context, key string) (time.Duration, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return 0, ErrNilClient
+	}
+	ttl, err := st.rdb.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	// go-redis passes the -2 and -1 replies through unscaled
+	switch ttl {
+	case -2:
+		return 0, ErrCacheMiss
+	case -1:
+		return NoExpiry, nil
+	}
+	return ttl, nil
+}
+
+// Inspect reports the existence and remaining TTL of each key, gathered
+// with a single pipeline of PTTL calls
+func (c *Client) Inspect(ctx context.Context, keys ...string) (map[string]KeyState, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return nil, ErrNilClient
+	}
+	states := make(map[string]KeyState, len(keys))
+	if len(keys) == 0 {
+		return states, nil
+	}
+
+	pipe := st.rdb.Pipeline()
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		ttls[i] = pipe.PTTL(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		// go-redis passes the -2 and -1 replies through unscaled
+		switch ttl := ttls[i].Val(); ttl {
+		case -2:
+			states[key] = KeyState{}
+		case -1:
+			states[key] = KeyState{Exists: true, TTL: NoExpiry}
+		default:
+			states[key] = KeyState{Exists: true, TTL: ttl}
+		}
+	}
+	return states, nil
+}
+
+// isUnsupportedExpireNX reports whether err is a pre-7.0 server rejecting
+// the NX flag on EXPIRE
+func isUnsupportedExpireNX(err error) bool {
+	return isWrongArity(err) || (err != nil && strings.Contains(err.Error(), "ERR Unsupported option"))
+}
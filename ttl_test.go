@@ -0,0 +1,223 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestExpireIfPersistent tests adding expiries only to persistent keys
+func TestExpireIfPersistent(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.ExpireIfPersistent(context.Background(), time.Minute, "k"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if _, err := client.ExpireIfPersistent(context.Background(), 0, "k"); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	persistent := "rediskit:test:expire:persistent"
+	expiring := "rediskit:test:expire:expiring"
+	missing := "rediskit:test:expire:missing"
+
+	setup := func() {
+		client.Del(ctx, persistent, expiring, missing)
+		client.Set(ctx, persistent, "v", 0)
+		client.Set(ctx, expiring, "v", 10*time.Hour)
+	}
+	defer client.Del(ctx, persistent, expiring)
+
+	check := func(t *testing.T, updated int, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated != 1 {
+			t.Errorf("updated %d keys, want 1", updated)
+		}
+		if ttl := client.TTL(ctx, persistent).Val(); ttl <= 0 || ttl > time.Hour {
+			t.Errorf("persistent key: expected ttl of about 1h, got %v", ttl)
+		}
+		if ttl := client.TTL(ctx, expiring).Val(); ttl <= time.Hour {
+			t.Errorf("expiring key: ttl was shortened to %v", ttl)
+		}
+		if n := client.Exists(ctx, missing).Val(); n != 0 {
+			t.Error("missing key was created")
+		}
+	}
+
+	t.Run("expire nx", func(t *testing.T) {
+		setup()
+		updated, err := client.ExpireIfPersistent(ctx, time.Hour, persistent, expiring, missing)
+		check(t, updated, err)
+	})
+
+	t.Run("lua fallback", func(t *testing.T) {
+		setup()
+		updated, err := expireIfPersistentScript.Run(ctx, client.Client,
+			[]string{persistent, expiring, missing}, time.Hour.Milliseconds()).Int()
+		check(t, updated, err)
+	})
+}
+
+// TestIsUnsupportedExpireNX tests detection of servers without EXPIRE NX
+func TestIsUnsupportedExpireNX(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("ERR wrong number of arguments for 'expire' command"), true},
+		{errors.New("ERR Unsupported option NX"), true},
+		{errors.New("ERR value is not an integer or out of range"), false},
+	}
+	for _, tt := range tests {
+		if got := isUnsupportedExpireNX(tt.err); got != tt.want {
+			t.Errorf("%v: got %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// TestInspect tests batch existence and TTL inspection
+func TestInspect(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.Inspect(context.Background(), "k"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	expiring := "rediskit:test:inspect:expiring"
+	persistent := "rediskit:test:inspect:persistent"
+	missing := "rediskit:test:inspect:missing"
+	defer client.Del(ctx, expiring, persistent, missing)
+
+	client.Set(ctx, expiring, "v", time.Hour)
+	client.Set(ctx, persistent, "v", 0)
+	client.Del(ctx, missing)
+
+	states, err := client.Inspect(ctx, expiring, persistent, missing)
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if len(states) != 3 {
+		t.Fatalf("expected 3 states, got %d", len(states))
+	}
+
+	if s := states[expiring]; !s.Exists || s.TTL <= 0 || s.TTL > time.Hour {
+		t.Errorf("expiring key: unexpected state %+v", s)
+	}
+	if s := states[persistent]; !s.Exists || s.TTL != NoExpiry {
+		t.Errorf("persistent key: expected exists with NoExpiry, got %+v", s)
+	}
+	if s := states[missing]; s.Exists || s.TTL != 0 {
+		t.Errorf("missing key: expected zero state, got %+v", s)
+	}
+
+	empty, err := client.Inspect(ctx)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("expected empty result for no keys, got %v, %v", empty, err)
+	}
+}
+
+// TestTTLRemaining tests the three TTL outcomes
+func TestTTLRemaining(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.TTLRemaining(context.Background(), "k"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	expiring := "rediskit:test:ttlremaining:expiring"
+	persistent := "rediskit:test:ttlremaining:persistent"
+	missing := "rediskit:test:ttlremaining:missing"
+	defer client.Del(ctx, expiring, persistent, missing)
+
+	client.Set(ctx, expiring, "v", time.Hour)
+	client.Set(ctx, persistent, "v", 0)
+	client.Del(ctx, missing)
+
+	tests := []struct {
+		name    string
+		key     string
+		check   func(time.Duration) bool
+		wantErr error
+	}{
+		{"expiring key", expiring, func(d time.Duration) bool { return d > 0 && d <= time.Hour }, nil},
+		{"persistent key", persistent, func(d time.Duration) bool { return d == NoExpiry }, nil},
+		{"missing key", missing, func(d time.Duration) bool { return d == 0 }, ErrCacheMiss},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, err := client.TTLRemaining(ctx, tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.check(ttl) {
+				t.Errorf("unexpected ttl %v", ttl)
+			}
+		})
+	}
+}
+
+// TestExpireAll tests setting a TTL on many keys at once
+func TestExpireAll(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.ExpireAll(context.Background(), time.Minute, "k"); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	if err := client.ExpireAll(ctx, 0, "k"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument for zero ttl, got %v", err)
+	}
+	if err := client.ExpireAll(ctx, time.Minute); err != nil {
+		t.Errorf("expected no error for no keys, got %v", err)
+	}
+
+	a := "rediskit:test:expireall:a"
+	b := "rediskit:test:expireall:b"
+	missing := "rediskit:test:expireall:missing"
+	defer client.Del(ctx, a, b, missing)
+	client.Set(ctx, a, "v", 0)
+	client.Set(ctx, b, "v", time.Hour)
+	client.Del(ctx, missing)
+
+	if err := client.ExpireAll(ctx, time.Minute, a, b, missing); err != nil {
+		t.Fatalf("ExpireAll: %v", err)
+	}
+	states, err := client.Inspect(ctx, a, b, missing)
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	for _, key := range []string{a, b} {
+		if s := states[key]; !s.Exists || s.TTL <= 0 || s.TTL > time.Minute {
+			t.Errorf("%s: expected a ttl of at most a minute, got %+v", key, s)
+		}
+	}
+	if states[missing].Exists {
+		t.Errorf("expected %s to stay missing", missing)
+	}
+}
@@ -0,0 +1,104 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Tx is one attempt of an optimistic transaction. Commands called on it
+// are queued and run atomically in MULTI/EXEC once the transaction
+// function returns; their results are available after that. Reads of the
+// watched keys go through Conn.
+type Tx struct {
+	redis.Pipeliner
+	conn *redis.Conn
+}
+
+// Conn returns the connection holding the WATCH. Commands sent on it run
+// immediately, so use it to read the watched keys before queuing writes.
+func (tx *Tx) Conn() *redis.Conn {
+	return tx.conn
+}
+
+// Transaction watches keys, calls fn to read them and queue commands on
+// tx, and executes the queued commands in MULTI/EXEC. If a watched key
+// changes before EXEC, fn is run again from scratch with a fresh Tx, up to
+// MaxRetries times with the client's retry backoff, after which
+// redis.TxFailedErr is returned. Any other error, including fn's own, is
+// returned as is without retrying, and the connection is Reset before it
+// goes back to the pool so that a MULTI or WATCH left open on it cannot
+// leak into the next command.
+func (c *Client) Transaction(ctx context.Context, keys []string, fn func(tx *Tx) error) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	if fn == nil {
+		return fmt.Errorf("%w: transaction function is nil", ErrInvalidArgument)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := c.attemptTx(ctx, st, keys, fn)
+		if !errors.Is(err, redis.TxFailedErr) || attempt >= st.config.MaxRetries {
+			return err
+		}
+
+		backoff := c.nextRetry("Transaction", attempt)
+		if logger := st.config.Logger; logger != nil {
+			logger.Debugf("transaction on %v conflicted, retrying in %s", keys, backoff)
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attemptTx runs one attempt of a transaction on a dedicated connection,
+// resetting it if the attempt fails other than by a conflict
+func (c *Client) attemptTx(ctx context.Context, st *clientState, keys []string, fn func(tx *Tx) error) error {
+	cn := st.rdb.Conn()
+	defer cn.Close()
+
+	err := runTx(ctx, cn, keys, fn)
+	if err != nil && !errors.Is(err, redis.TxFailedErr) {
+		resetCtx, cancel := c.ctxWithTimeout(context.WithoutCancel(ctx))
+		defer cancel()
+		if resetErr := c.Reset(resetCtx, cn); resetErr != nil && st.config.Logger != nil {
+			st.config.Logger.Warnf("resetting connection after failed transaction on %v: %v", keys, resetErr)
+		}
+	}
+	return err
+}
+
+// runTx watches keys on cn, calls fn, and executes what it queued
+func runTx(ctx context.Context, cn *redis.Conn, keys []string, fn func(tx *Tx) error) error {
+	if len(keys) > 0 {
+		args := make([]any, 0, len(keys)+1)
+		args = append(args, "watch")
+		for _, key := range keys {
+			args = append(args, key)
+		}
+		if err := cn.Do(ctx, args...).Err(); err != nil {
+			return err
+		}
+	}
+
+	pipe := cn.TxPipeline()
+	if err := fn(&Tx{Pipeliner: pipe, conn: cn}); err != nil {
+		return err
+	}
+	if pipe.Len() == 0 {
+		// EXEC would have released the watches
+		return cn.Do(ctx, "unwatch").Err()
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
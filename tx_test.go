@@ -0,0 +1,156 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestTransaction tests optimistic transactions with WATCH
+func TestTransaction(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.Transaction(context.Background(), nil, func(*Tx) error { return nil }); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		client, err := NewClient(nil)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if err := client.Transaction(context.Background(), nil, nil); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected ErrInvalidArgument, got %v", err)
+		}
+	})
+
+	newTestClient(t) // skips without Redis
+	cfg := DefaultConfig()
+	cfg.MaxRetries = 2
+	cfg.MinRetryBackoff = time.Millisecond
+	cfg.MaxRetryBackoff = time.Millisecond
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	key := "rediskit:test:tx:counter"
+	defer client.Del(ctx, key)
+
+	// incr doubles the counter, writing concurrently on the first
+	// interfere attempts to make the transaction conflict
+	incr := func(attempts *int, interfere int) func(tx *Tx) error {
+		return func(tx *Tx) error {
+			*attempts++
+			n, err := tx.Conn().Get(ctx, key).Int()
+			if err != nil {
+				return err
+			}
+			if *attempts <= interfere {
+				if err := client.Set(ctx, key, n+100, 0).Err(); err != nil {
+					return err
+				}
+			}
+			tx.Set(ctx, key, n*2, 0)
+			return nil
+		}
+	}
+
+	t.Run("conflict is retried and then succeeds", func(t *testing.T) {
+		client.Set(ctx, key, 1, 0)
+		attempts := 0
+		if err := client.Transaction(ctx, []string{key}, incr(&attempts, 1)); err != nil {
+			t.Fatalf("Transaction: %v", err)
+		}
+		if attempts != 2 {
+			t.Errorf("expected 2 attempts, got %d", attempts)
+		}
+		// The retry read the concurrently written 101
+		if v, _ := client.Get(ctx, key).Int(); v != 202 {
+			t.Errorf("expected 202, got %d", v)
+		}
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		client.Set(ctx, key, 1, 0)
+		attempts := 0
+		err := client.Transaction(ctx, []string{key}, incr(&attempts, 100))
+		if !errors.Is(err, redis.TxFailedErr) {
+			t.Errorf("expected TxFailedErr, got %v", err)
+		}
+		if attempts != cfg.MaxRetries+1 {
+			t.Errorf("expected %d attempts, got %d", cfg.MaxRetries+1, attempts)
+		}
+	})
+
+	t.Run("function error is returned without retrying", func(t *testing.T) {
+		errAbort := errors.New("abort")
+		attempts := 0
+		err := client.Transaction(ctx, []string{key}, func(tx *Tx) error {
+			attempts++
+			tx.Set(ctx, key, "never written", 0)
+			return errAbort
+		})
+		if err != errAbort {
+			t.Errorf("expected the function's error, got %v", err)
+		}
+		if attempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", attempts)
+		}
+		if v := client.Get(ctx, key).Val(); v == "never written" {
+			t.Error("expected queued commands to be discarded")
+		}
+	})
+
+	t.Run("queued results are available", func(t *testing.T) {
+		client.Set(ctx, key, 5, 0)
+		var incrBy *redis.IntCmd
+		err := client.Transaction(ctx, []string{key}, func(tx *Tx) error {
+			incrBy = tx.IncrBy(ctx, key, 3)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Transaction: %v", err)
+		}
+		if v := incrBy.Val(); v != 8 {
+			t.Errorf("expected 8, got %d", v)
+		}
+	})
+
+	t.Run("failed attempt resets the connection", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.PoolSize = 1
+		cfg.MinIdleConns = 0
+		single, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer single.Close()
+
+		errAbort := errors.New("abort")
+		err = single.Transaction(ctx, []string{key}, func(tx *Tx) error {
+			// Leave the connection inside a transaction
+			if err := tx.Conn().Do(ctx, "multi").Err(); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		if err != errAbort {
+			t.Fatalf("expected the function's error, got %v", err)
+		}
+
+		// With one pooled connection this reuses the aborted one
+		if err := single.Set(ctx, key, "direct", 0).Err(); err != nil {
+			t.Fatalf("SET: %v", err)
+		}
+		if v := single.Get(ctx, key).Val(); v != "direct" {
+			t.Errorf("expected direct write, got %q", v)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package rediskit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNewConfigFromURL tests parsing connection URLs
+func TestNewConfigFromURL(t *testing.T) {
+	t.Run("full url", func(t *testing.T) {
+		cfg, err := NewConfigFromURL("redis://:s%40cret@cache.example.com:6380/2?pool_size=30&min_idle_conns=4&dial_timeout=2s&socket_timeout=3&read_timeout=500ms&write_timeout=1s")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		tests := []struct {
+			name     string
+			got      interface{}
+			expected interface{}
+		}{
+			{"Host", cfg.Host, "cache.example.com"},
+			{"Port", cfg.Port, "6380"},
+			{"Password", cfg.Password, "s@cret"},
+			{"DB", cfg.DB, 2},
+			{"PoolSize", cfg.PoolSize, 30},
+			{"MinIdleConns", cfg.MinIdleConns, 4},
+			{"SocketConnectTimeout", cfg.SocketConnectTimeout, 2 * time.Second},
+			{"SocketTimeout", cfg.SocketTimeout, 3 * time.Second},
+			{"ReadTimeout", cfg.ReadTimeout, 500 * time.Millisecond},
+			{"WriteTimeout", cfg.WriteTimeout, time.Second},
+			{"EnableTLS", cfg.EnableTLS, false},
+			{"DefaultTimeout", cfg.DefaultTimeout, 5 * time.Second},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if tt.got != tt.expected {
+					t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.expected)
+				}
+			})
+		}
+	})
+
+	t.Run("minimal url uses defaults", func(t *testing.T) {
+		cfg, err := NewConfigFromURL("redis://redis-server")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Host != "redis-server" || cfg.Port != "6379" || cfg.DB != 0 || cfg.Password != "" {
+			t.Errorf("unexpected config %+v", cfg)
+		}
+	})
+
+	t.Run("rediss enables tls", func(t *testing.T) {
+		cfg, err := NewConfigFromURL("rediss://user:pw@secure-host:6390")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !cfg.EnableTLS {
+			t.Error("expected EnableTLS for rediss scheme")
+		}
+		if cfg.Password != "pw" {
+			t.Errorf("expected password pw, got %q", cfg.Password)
+		}
+		if cfg.Username != "user" {
+			t.Errorf("expected username user, got %q", cfg.Username)
+		}
+	})
+
+	errTests := []struct {
+		name      string
+		url       string
+		errString string
+	}{
+		{"unknown scheme", "http://localhost:6379", "unknown url scheme"},
+		{"non-numeric port", "redis://localhost:abc", "parse url"},
+		{"non-numeric db", "redis://localhost:6379/x", "db \"x\" is not numeric"},
+		{"bad pool size", "redis://localhost?pool_size=many", "invalid pool_size"},
+		{"bad duration", "redis://localhost?dial_timeout=soon", "invalid dial_timeout"},
+		{"unknown parameter", "redis://localhost?foo=bar", "unknown url parameter"},
+		{"invalid result", "redis://localhost?pool_size=0", "pool size must be greater than 0"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfigFromURL(tt.url)
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("expected ErrInvalidConfig, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tt.errString) {
+				t.Errorf("expected error to contain %q, got %q", tt.errString, err.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type versionedDoc struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// TestVersioned tests optimistic concurrency with versioned values
+func TestVersioned(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		var doc versionedDoc
+		if _, err := client.GetVersioned(context.Background(), "k", &doc); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+		if _, _, err := client.SetVersioned(context.Background(), "k", doc, 0, 0); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	key := "rediskit:test:versioned"
+	client.Del(ctx, key)
+	defer client.Del(ctx, key)
+
+	t.Run("missing key", func(t *testing.T) {
+		var doc versionedDoc
+		if _, err := client.GetVersioned(ctx, key, &doc); !errors.Is(err, ErrCacheMiss) {
+			t.Errorf("expected ErrCacheMiss, got %v", err)
+		}
+	})
+
+	t.Run("create and update", func(t *testing.T) {
+		ok, ver, err := client.SetVersioned(ctx, key, versionedDoc{Name: "a", Count: 1}, 0, time.Hour)
+		if err != nil || !ok || ver != 1 {
+			t.Fatalf("create: got ok=%v ver=%d err=%v", ok, ver, err)
+		}
+
+		var doc versionedDoc
+		ver, err = client.GetVersioned(ctx, key, &doc)
+		if err != nil {
+			t.Fatalf("GetVersioned: %v", err)
+		}
+		if ver != 1 || doc.Name != "a" || doc.Count != 1 {
+			t.Errorf("got ver=%d doc=%+v", ver, doc)
+		}
+
+		ok, ver, err = client.SetVersioned(ctx, key, versionedDoc{Name: "a", Count: 2}, 1, time.Hour)
+		if err != nil || !ok || ver != 2 {
+			t.Fatalf("update: got ok=%v ver=%d err=%v", ok, ver, err)
+		}
+	})
+
+	t.Run("stale version loses", func(t *testing.T) {
+		ok, ver, err := client.SetVersioned(ctx, key, versionedDoc{Name: "stale"}, 1, time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok {
+			t.Error("expected write with stale version to be rejected")
+		}
+		if ver != 2 {
+			t.Errorf("expected current version 2, got %d", ver)
+		}
+
+		var doc versionedDoc
+		if _, err := client.GetVersioned(ctx, key, &doc); err != nil {
+			t.Fatalf("GetVersioned: %v", err)
+		}
+		if doc.Count != 2 {
+			t.Errorf("rejected write changed the value: %+v", doc)
+		}
+	})
+}
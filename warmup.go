@@ -0,0 +1,49 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Warmup eagerly opens MinIdleConns pool connections by pinging on that
+// many connections at once, so early requests do not pay for dialing.
+// Connections that came up stay in the pool; failures are joined into the
+// returned error. DefaultTimeout applies when ctx has no deadline.
+func (c *Client) Warmup(ctx context.Context) error {
+	st := c.load()
+	if st.rdb == nil {
+		return ErrNilClient
+	}
+	n := st.rdb.Options().MinIdleConns
+	if n <= 0 {
+		return nil
+	}
+	ctx, cancel := c.ctxWithTimeout(ctx)
+	defer cancel()
+
+	// Every connection is held until all pings finish so that each ping
+	// needs a connection of its own
+	conns := make([]*redis.Conn, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range conns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conns[i] = st.rdb.Conn()
+			if err := conns[i].Ping(ctx).Err(); err != nil {
+				errs[i] = fmt.Errorf("warm connection %d: %w", i+1, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, cn := range conns {
+		cn.Close()
+	}
+	return errors.Join(errs...)
+}
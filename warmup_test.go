@@ -0,0 +1,74 @@
+package rediskit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestWarmup tests eagerly filling the connection pool
+func TestWarmup(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if err := client.Warmup(context.Background()); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	t.Run("no min idle conns is a no-op", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Port = "1" // nothing listens here
+		cfg.MinIdleConns = 0
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+		if err := client.Warmup(context.Background()); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("unreachable server returns joined errors", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.Port = "1"
+		cfg.MinIdleConns = 3
+		cfg.MaxRetries = -1
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err = client.Warmup(ctx)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		var joined interface{ Unwrap() []error }
+		if !errors.As(err, &joined) || len(joined.Unwrap()) != cfg.MinIdleConns {
+			t.Errorf("expected one error per connection, got %v", err)
+		}
+	})
+
+	t.Run("fills the pool", func(t *testing.T) {
+		newTestClient(t) // skips without Redis
+		cfg := DefaultConfig()
+		cfg.MinIdleConns = 5
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		defer client.Close()
+
+		if err := client.Warmup(context.Background()); err != nil {
+			t.Fatalf("Warmup: %v", err)
+		}
+		stats := client.PoolStats()
+		if stats.IdleConns < uint32(cfg.MinIdleConns) {
+			t.Errorf("expected at least %d idle connections, got %d", cfg.MinIdleConns, stats.IdleConns)
+		}
+	})
+}
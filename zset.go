@@ -0,0 +1,33 @@
+package rediskit
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// zMoveScript removes ARGV[1] from the sorted set KEYS[1] and adds it to
+// KEYS[2] with score ARGV[2], returning 0 if it was not in KEYS[1]
+var zMoveScript = redis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('ZADD', KEYS[2], ARGV[2], ARGV[1])
+return 1
+`)
+
+// ZMove atomically moves member from the sorted set src to dst with
+// newScore, returning false without touching dst if member is not in src
+func (c *Client) ZMove(ctx context.Context, src, dst, member string, newScore float64) (bool, error) {
+	st := c.load()
+	if st.rdb == nil {
+		return false, ErrNilClient
+	}
+	ctx, cancel := c.writeContext(ctx)
+	defer cancel()
+	n, err := zMoveScript.Run(ctx, st.rdb, []string{src, dst}, member, newScore).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
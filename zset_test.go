@@ -0,0 +1,62 @@
+package rediskit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TestZMove tests moving members between sorted sets
+func TestZMove(t *testing.T) {
+	t.Run("nil client returns error", func(t *testing.T) {
+		client := clientWith(nil, DefaultConfig())
+		if _, err := client.ZMove(context.Background(), "a", "b", "m", 1); err != ErrNilClient {
+			t.Errorf("expected ErrNilClient, got %v", err)
+		}
+	})
+
+	client := newTestClient(t)
+	ctx := context.Background()
+	src := "rediskit:test:zmove:src"
+	dst := "rediskit:test:zmove:dst"
+	defer client.Del(ctx, src, dst)
+
+	t.Run("moves a present member", func(t *testing.T) {
+		client.Del(ctx, src, dst)
+		client.ZAdd(ctx, src, redis.Z{Score: 1, Member: "task"}, redis.Z{Score: 2, Member: "other"})
+
+		moved, err := client.ZMove(ctx, src, dst, "task", 10.5)
+		if err != nil {
+			t.Fatalf("ZMove: %v", err)
+		}
+		if !moved {
+			t.Fatal("expected member to be moved")
+		}
+		if err := client.ZScore(ctx, src, "task").Err(); err != redis.Nil {
+			t.Errorf("expected member to be gone from src, got %v", err)
+		}
+		if score := client.ZScore(ctx, dst, "task").Val(); score != 10.5 {
+			t.Errorf("expected score 10.5 in dst, got %v", score)
+		}
+		if n := client.ZCard(ctx, src).Val(); n != 1 {
+			t.Errorf("expected other members to stay in src, got %d", n)
+		}
+	})
+
+	t.Run("absent member", func(t *testing.T) {
+		client.Del(ctx, src, dst)
+		client.ZAdd(ctx, src, redis.Z{Score: 1, Member: "other"})
+
+		moved, err := client.ZMove(ctx, src, dst, "task", 3)
+		if err != nil {
+			t.Fatalf("ZMove: %v", err)
+		}
+		if moved {
+			t.Error("expected no move for an absent member")
+		}
+		if n := client.Exists(ctx, dst).Val(); n != 0 {
+			t.Error("expected dst to be untouched")
+		}
+	})
+}